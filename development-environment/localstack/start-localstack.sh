@@ -15,4 +15,7 @@ awslocal sns subscribe --topic-arn arn:aws:sns:us-east-1:000000000000:COLIBRI_PR
 
 awslocal s3api create-bucket --bucket my-bucket --acl public-read
 
+awslocal secretsmanager create-secret --name colibri-project/database \
+         --secret-string '{"username":"colibri","password":"colibri-secret-password"}'
+
 echo "localstack emulator started"
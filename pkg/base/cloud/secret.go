@@ -0,0 +1,108 @@
+package cloud
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	gcpsecretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+const (
+	SECRET_SCHEME_AWS_SECRETS_MANAGER string = "awssm"
+	SECRET_SCHEME_GCP_SECRET_MANAGER  string = "gcpsm"
+
+	gcpSecretVersionName        string = "projects/%s/secrets/%s/versions/%s"
+	gcpSecretDefaultVersion     string = "latest"
+	errorSecretKeyNotFound      string = "key %s not found in secret %s"
+	errorSecretInvalidReference string = "invalid secret reference %s, expected %s://project/secret[/version]"
+)
+
+func init() {
+	config.RegisterSecretResolver(SECRET_SCHEME_AWS_SECRETS_MANAGER, config.SecretResolverFunc(resolveAwsSecret))
+	config.RegisterSecretResolver(SECRET_SCHEME_GCP_SECRET_MANAGER, config.SecretResolverFunc(resolveGcpSecret))
+}
+
+// resolveAwsSecret reads a secret from AWS Secrets Manager. The reference format is awssm://name#key,
+// where the key is optional and selects a field of a JSON secret.
+func resolveAwsSecret(ctx context.Context, reference *url.URL) (string, error) {
+	name := reference.Host + reference.Path
+	output, err := secretsmanager.New(getOrCreateAwsSession()).GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(name),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return secretKeyValue(name, aws.StringValue(output.SecretString), reference.Fragment)
+}
+
+// resolveGcpSecret reads a secret from GCP Secret Manager. The reference format is gcpsm://project/secret[/version]#key,
+// where the version defaults to latest and the key is optional and selects a field of a JSON secret.
+func resolveGcpSecret(ctx context.Context, reference *url.URL) (string, error) {
+	path := strings.Split(strings.Trim(reference.Path, "/"), "/")
+	if reference.Host == "" || path[0] == "" || len(path) > 2 {
+		return "", fmt.Errorf(errorSecretInvalidReference, reference.Redacted(), SECRET_SCHEME_GCP_SECRET_MANAGER)
+	}
+
+	version := gcpSecretDefaultVersion
+	if len(path) == 2 {
+		version = path[1]
+	}
+
+	service, err := gcpsecretmanager.NewService(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf(gcpSecretVersionName, reference.Host, path[0], version)
+	response, err := service.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+
+	value, err := base64.StdEncoding.DecodeString(response.Payload.Data)
+	if err != nil {
+		return "", err
+	}
+
+	return secretKeyValue(name, string(value), reference.Fragment)
+}
+
+// secretKeyValue returns the field key of a JSON secret, or the whole secret when key is empty.
+func secretKeyValue(name, secret, key string) (string, error) {
+	if key == "" {
+		return secret, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", err
+	}
+
+	value, exists := fields[key]
+	if !exists {
+		return "", fmt.Errorf(errorSecretKeyNotFound, key, name)
+	}
+
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// getOrCreateAwsSession returns the initialized AWS session, or creates a new one when
+// secrets are resolved during the configuration load, before the cloud initialization.
+func getOrCreateAwsSession() *session.Session {
+	if instance != nil && instance.aws != nil {
+		return instance.aws
+	}
+	return newAwsSession()
+}
@@ -0,0 +1,54 @@
+package cloud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwsSecretResolver(t *testing.T) {
+	ctx := context.Background()
+	test.InitializeTestLocalstack()
+
+	t.Run("Should resolve whole secret from secrets manager", func(t *testing.T) {
+		result, err := config.ResolveSecret(ctx, "awssm://colibri-project/database")
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"username":"colibri","password":"colibri-secret-password"}`, result)
+	})
+
+	t.Run("Should resolve secret key from secrets manager", func(t *testing.T) {
+		result, err := config.ResolveSecret(ctx, "awssm://colibri-project/database#password")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "colibri-secret-password", result)
+	})
+
+	t.Run("Should return error when secret key does not exist", func(t *testing.T) {
+		result, err := config.ResolveSecret(ctx, "awssm://colibri-project/database#not-found")
+
+		assert.EqualError(t, err, "key not-found not found in secret colibri-project/database")
+		assert.Empty(t, result)
+	})
+
+	t.Run("Should return error when secret does not exist", func(t *testing.T) {
+		result, err := config.ResolveSecret(ctx, "awssm://colibri-project/not-found")
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestGcpSecretResolver(t *testing.T) {
+	t.Run("Should return error when secret reference is invalid", func(t *testing.T) {
+		for _, reference := range []string{"gcpsm://project", "gcpsm:///secret", "gcpsm://project/secret/1/extra"} {
+			result, err := config.ResolveSecret(context.Background(), reference)
+
+			assert.ErrorContains(t, err, "invalid secret reference")
+			assert.Empty(t, result)
+		}
+	})
+}
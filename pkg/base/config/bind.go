@@ -0,0 +1,213 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	bind_tag_env      string = "env"
+	bind_tag_default  string = "default"
+	bind_tag_required string = "required"
+	bind_tag_prefix   string = "prefix"
+	bind_tag_secret   string = "secret"
+	bind_separator    string = ","
+
+	error_bind_invalid_target string = "could not bind configuration, target must be a non-nil pointer to a struct, got %T"
+	error_required_param      string = "%s is required but not configured"
+	error_unsupported_type    string = "could not parse %s, unsupported type %s"
+	error_unsigned_parse      string = "could not parse %s, permitted unsigned int value, got %v: %w"
+	error_float_parse         string = "could not parse %s, permitted float value, got %v: %w"
+	error_duration_parse      string = "could not parse %s, permitted duration value (ex: 10s, 1m), got %v: %w"
+	error_url_parse           string = "could not parse %s, permitted url value, got %v: %w"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlType      = reflect.TypeOf(url.URL{})
+)
+
+// Bind loads the environment variables into the struct pointed by target using its field tags.
+//
+// The supported tags are:
+// - env: the environment variable name. Fields without env tag are ignored, except nested structs.
+// - default: the value used when the environment variable is not set.
+// - required: when "true", returns an error if the environment variable and the default are empty.
+// - prefix: used in nested structs, prepends the value to the env name of all nested fields.
+// - secret: when "true", resolves the value as a secret reference (ex: file:///run/secrets/db).
+//
+// The supported field types are string, bool, int, uint, float, time.Duration, url.URL, slices of them (comma separated)
+// and nested structs. All invalid or missing values are reported together in a single error.
+func Bind(target any) error {
+	return BindWithPrefix("", target)
+}
+
+// BindWithPrefix loads the environment variables into the struct pointed by target prepending prefix to all env names.
+func BindWithPrefix(prefix string, target any) error {
+	if err := loadEnvFiles(); err != nil {
+		return err
+	}
+
+	return bind(prefix, target)
+}
+
+// bind fills the struct pointed by target without loading the env files.
+func bind(prefix string, target any) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf(error_bind_invalid_target, target)
+	}
+
+	return errors.Join(bindStruct(value.Elem(), prefix)...)
+}
+
+// bindStruct fills all tagged fields of a struct value and returns the errors found.
+func bindStruct(value reflect.Value, prefix string) (errs []error) {
+	for i := 0; i < value.NumField(); i++ {
+		fieldType := value.Type().Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		field := value.Field(i)
+		envName, hasEnv := fieldType.Tag.Lookup(bind_tag_env)
+		if !hasEnv {
+			if nested, ok := nestedStruct(field); ok {
+				errs = append(errs, bindStruct(nested, prefix+fieldType.Tag.Get(bind_tag_prefix))...)
+			}
+			continue
+		}
+
+		envName = prefix + envName
+		envValue, exists := os.LookupEnv(envName)
+		if !exists || envValue == "" {
+			envValue = fieldType.Tag.Get(bind_tag_default)
+		}
+
+		if envValue == "" {
+			if fieldType.Tag.Get(bind_tag_required) == "true" {
+				errs = append(errs, fmt.Errorf(error_required_param, envName))
+			}
+			continue
+		}
+
+		if fieldType.Tag.Get(bind_tag_secret) == "true" {
+			secret, err := ResolveSecret(context.Background(), envValue)
+			if err != nil {
+				errs = append(errs, fmt.Errorf(error_secret_resolve, envName, err))
+				continue
+			}
+			envValue = secret
+		}
+
+		if err := setFieldValue(field, envName, envValue); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// nestedStruct returns the struct value of a field without env tag, allocating nil struct pointers.
+func nestedStruct(field reflect.Value) (reflect.Value, bool) {
+	if field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct && field.Type().Elem() != urlType {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return field.Elem(), true
+	}
+
+	if field.Kind() == reflect.Struct && field.Type() != urlType {
+		return field, true
+	}
+
+	return reflect.Value{}, false
+}
+
+// setFieldValue converts the environment value to the field type and sets it.
+func setFieldValue(field reflect.Value, envName, envValue string) error {
+	if field.Kind() == reflect.Pointer {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setFieldValue(ptr.Elem(), envName, envValue); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	if field.Kind() == reflect.Slice {
+		items := strings.Split(envValue, bind_separator)
+		slice := reflect.MakeSlice(field.Type(), 0, len(items))
+		for _, item := range items {
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setFieldValue(elem, envName, strings.TrimSpace(item)); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	switch {
+	case field.Type() == durationType:
+		duration, err := time.ParseDuration(envValue)
+		if err != nil {
+			return fmt.Errorf(error_duration_parse, envName, envValue, err)
+		}
+		field.SetInt(int64(duration))
+	case field.Type() == urlType:
+		parsed, err := url.Parse(envValue)
+		if err != nil {
+			return fmt.Errorf(error_url_parse, envName, envValue, err)
+		}
+		field.Set(reflect.ValueOf(*parsed))
+	default:
+		return setBasicFieldValue(field, envName, envValue)
+	}
+
+	return nil
+}
+
+// setBasicFieldValue converts the environment value to a basic kind (string, bool, int, uint or float) and sets it.
+func setBasicFieldValue(field reflect.Value, envName, envValue string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(envValue)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(envValue)
+		if err != nil {
+			return fmt.Errorf(error_boolean_parse, envName, envValue, err)
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(envValue, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf(error_integer_parse, envName, envValue, err)
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(envValue, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf(error_unsigned_parse, envName, envValue, err)
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(envValue, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf(error_float_parse, envName, envValue, err)
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf(error_unsupported_type, envName, field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bindDatabaseTest struct {
+	Host string `env:"HOST" required:"true"`
+	Port int    `env:"PORT" default:"5432"`
+}
+
+type bindTest struct {
+	Name     string            `env:"BIND_NAME" required:"true"`
+	Workers  int               `env:"BIND_WORKERS" default:"10"`
+	Enabled  bool              `env:"BIND_ENABLED"`
+	Ratio    float64           `env:"BIND_RATIO"`
+	Retries  uint8             `env:"BIND_RETRIES"`
+	Timeout  time.Duration     `env:"BIND_TIMEOUT" default:"30s"`
+	Tags     []string          `env:"BIND_TAGS"`
+	Ports    []int             `env:"BIND_PORTS"`
+	Endpoint url.URL           `env:"BIND_ENDPOINT"`
+	Callback *url.URL          `env:"BIND_CALLBACK"`
+	Database bindDatabaseTest  `prefix:"BIND_DB_"`
+	Replica  *bindDatabaseTest `prefix:"BIND_REPLICA_"`
+	ignored  string            `env:"BIND_IGNORED"`
+}
+
+func TestBind(t *testing.T) {
+	t.Run("Should return error when target is not a pointer to struct", func(t *testing.T) {
+		var target bindTest
+
+		assert.EqualError(t, Bind(target), fmt.Sprintf(error_bind_invalid_target, target))
+		assert.Error(t, Bind(nil))
+	})
+
+	t.Run("Should bind all fields with success", func(t *testing.T) {
+		t.Setenv("BIND_NAME", app_name_value)
+		t.Setenv("BIND_ENABLED", "true")
+		t.Setenv("BIND_RATIO", "0.75")
+		t.Setenv("BIND_RETRIES", "3")
+		t.Setenv("BIND_TIMEOUT", "1m")
+		t.Setenv("BIND_TAGS", "a, b,c")
+		t.Setenv("BIND_PORTS", "80,443")
+		t.Setenv("BIND_ENDPOINT", cloud_host_value)
+		t.Setenv("BIND_CALLBACK", cloud_host_value+"/callback")
+		t.Setenv("BIND_DB_HOST", sql_db_host_value)
+		t.Setenv("BIND_REPLICA_HOST", sql_db_host_value+"-replica")
+		t.Setenv("BIND_REPLICA_PORT", sql_db_port_value)
+		t.Setenv("BIND_IGNORED", invalid_value)
+
+		var target bindTest
+		assert.NoError(t, Bind(&target))
+		assert.Equal(t, app_name_value, target.Name)
+		assert.Equal(t, 10, target.Workers)
+		assert.True(t, target.Enabled)
+		assert.Equal(t, 0.75, target.Ratio)
+		assert.Equal(t, uint8(3), target.Retries)
+		assert.Equal(t, time.Minute, target.Timeout)
+		assert.Equal(t, []string{"a", "b", "c"}, target.Tags)
+		assert.Equal(t, []int{80, 443}, target.Ports)
+		assert.Equal(t, cloud_host_value, target.Endpoint.String())
+		assert.Equal(t, cloud_host_value+"/callback", target.Callback.String())
+		assert.Equal(t, sql_db_host_value, target.Database.Host)
+		assert.Equal(t, 5432, target.Database.Port)
+		assert.Equal(t, sql_db_host_value+"-replica", target.Replica.Host)
+		assert.Equal(t, 1234, target.Replica.Port)
+		assert.Empty(t, target.ignored)
+	})
+
+	t.Run("Should bind fields with prefix", func(t *testing.T) {
+		t.Setenv("REPORTING_HOST", sql_db_host_value)
+
+		var target bindDatabaseTest
+		assert.NoError(t, BindWithPrefix("REPORTING_", &target))
+		assert.Equal(t, sql_db_host_value, target.Host)
+		assert.Equal(t, 5432, target.Port)
+	})
+
+	t.Run("Should return all errors when required fields are missing and values are invalid", func(t *testing.T) {
+		t.Setenv("BIND_WORKERS", invalid_value)
+		t.Setenv("BIND_ENABLED", invalid_value)
+		t.Setenv("BIND_TIMEOUT", invalid_value)
+		t.Setenv("BIND_ENDPOINT", "://"+invalid_value)
+
+		var target bindTest
+		err := Bind(&target)
+		assert.ErrorContains(t, err, fmt.Sprintf(error_required_param, "BIND_NAME"))
+		assert.ErrorContains(t, err, fmt.Sprintf(error_required_param, "BIND_DB_HOST"))
+		assert.ErrorContains(t, err, fmt.Sprintf(error_required_param, "BIND_REPLICA_HOST"))
+		assert.ErrorContains(t, err, "could not parse BIND_WORKERS, permitted int value")
+		assert.ErrorContains(t, err, "could not parse BIND_ENABLED, permitted 'true' or 'false'")
+		assert.ErrorContains(t, err, "could not parse BIND_TIMEOUT, permitted duration value")
+		assert.ErrorContains(t, err, "could not parse BIND_ENDPOINT, permitted url value")
+	})
+
+	t.Run("Should return error when field type is not supported", func(t *testing.T) {
+		t.Setenv("BIND_UNSUPPORTED", invalid_value)
+
+		var target struct {
+			Unsupported map[string]string `env:"BIND_UNSUPPORTED"`
+		}
+		assert.EqualError(t, Bind(&target), fmt.Sprintf(error_unsupported_type, "BIND_UNSUPPORTED", "map[string]string"))
+	})
+}
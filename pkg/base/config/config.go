@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"golang.org/x/exp/slices"
@@ -22,47 +23,60 @@ const (
 	ENV_OTEL_EXPORTER_OTLP_ENDPOINT string = "OTEL_EXPORTER_OTLP_ENDPOINT"
 	ENV_OTEL_EXPORTER_OTLP_HEADERS  string = "OTEL_EXPORTER_OTLP_HEADERS"
 
-	ENV_PORT                  string = "PORT"
-	ENV_SQL_DB_MIGRATION      string = "SQL_DB_MIGRATION"
-	ENV_CLOUD_HOST            string = "CLOUD_HOST"
-	ENV_CLOUD_REGION          string = "CLOUD_REGION"
-	ENV_CLOUD_SECRET          string = "CLOUD_SECRET"
-	ENV_CLOUD_TOKEN           string = "CLOUD_TOKEN"
-	ENV_CLOUD_DISABLE_SSL     string = "CLOUD_DISABLE_SSL"
-	ENV_CACHE_URI             string = "CACHE_URI"
-	ENV_CACHE_PASSWORD        string = "CACHE_PASSWORD"
-	ENV_SQL_DB_NAME           string = "SQL_DB_NAME"
-	ENV_SQL_DB_HOST           string = "SQL_DB_HOST"
-	ENV_SQL_DB_PORT           string = "SQL_DB_PORT"
-	ENV_SQL_DB_USER           string = "SQL_DB_USER"
-	ENV_SQL_DB_PASSWORD       string = "SQL_DB_PASSWORD"
-	ENV_SQL_DB_SSL_MODE       string = "SQL_DB_SSL_MODE"
-	ENV_SQL_DB_MAX_OPEN_CONNS string = "SQL_DB_MAX_OPEN_CONNS"
-	ENV_SQL_DB_MAX_IDLE_CONNS string = "SQL_DB_MAX_IDLE_CONNS"
-	ENV_LOG_LEVEL             string = "LOG_LEVEL"
+	ENV_PORT                                string = "PORT"
+	ENV_SQL_DB_MIGRATION                    string = "SQL_DB_MIGRATION"
+	ENV_CLOUD_HOST                          string = "CLOUD_HOST"
+	ENV_CLOUD_REGION                        string = "CLOUD_REGION"
+	ENV_CLOUD_SECRET                        string = "CLOUD_SECRET"
+	ENV_CLOUD_TOKEN                         string = "CLOUD_TOKEN"
+	ENV_CLOUD_DISABLE_SSL                   string = "CLOUD_DISABLE_SSL"
+	ENV_CACHE_URI                           string = "CACHE_URI"
+	ENV_CACHE_PASSWORD                      string = "CACHE_PASSWORD"
+	ENV_SQL_DB_NAME                         string = "SQL_DB_NAME"
+	ENV_SQL_DB_HOST                         string = "SQL_DB_HOST"
+	ENV_SQL_DB_PORT                         string = "SQL_DB_PORT"
+	ENV_SQL_DB_USER                         string = "SQL_DB_USER"
+	ENV_SQL_DB_PASSWORD                     string = "SQL_DB_PASSWORD"
+	ENV_SQL_DB_SSL_MODE                     string = "SQL_DB_SSL_MODE"
+	ENV_SQL_DB_MAX_OPEN_CONNS               string = "SQL_DB_MAX_OPEN_CONNS"
+	ENV_SQL_DB_MAX_IDLE_CONNS               string = "SQL_DB_MAX_IDLE_CONNS"
+	ENV_SQL_DB_REPLICA_HOSTS                string = "SQL_DB_REPLICA_HOSTS"
+	ENV_SQL_DB_REPLICA_STRATEGY             string = "SQL_DB_REPLICA_STRATEGY"
+	ENV_SQL_DB_REPLICA_HEALTH_CHECK_SECONDS string = "SQL_DB_REPLICA_HEALTH_CHECK_SECONDS"
+	ENV_SQL_DB_STRICT_COLUMN_MAPPING        string = "SQL_DB_STRICT_COLUMN_MAPPING"
+	ENV_SQL_DB_SLOW_QUERY_THRESHOLD_MS      string = "SQL_DB_SLOW_QUERY_THRESHOLD_MS"
+	ENV_SQL_DB_QUERY_METRICS                string = "SQL_DB_QUERY_METRICS"
+	ENV_SQL_DB_STATEMENT_CACHE_SIZE         string = "SQL_DB_STATEMENT_CACHE_SIZE"
+	ENV_MESSAGING_OUTBOX_ENABLED            string = "MESSAGING_OUTBOX_ENABLED"
+	ENV_MESSAGING_OUTBOX_INTERVAL_SECONDS   string = "MESSAGING_OUTBOX_INTERVAL_SECONDS"
+	ENV_MESSAGING_OUTBOX_BATCH_SIZE         string = "MESSAGING_OUTBOX_BATCH_SIZE"
+	ENV_LOG_LEVEL                           string = "LOG_LEVEL"
 
 	// Environment values
-	ENVIRONMENT_PRODUCTION        string = "production"
-	ENVIRONMENT_SANDBOX           string = "sandbox"
-	ENVIRONMENT_DEVELOPMENT       string = "development"
-	ENVIRONMENT_TEST              string = "test"
-	APP_TYPE_SERVICE              string = "service"
-	APP_TYPE_SERVERLESS           string = "serverless"
-	CLOUD_AWS                     string = "aws"
-	CLOUD_AZURE                   string = "azure"
-	CLOUD_GCP                     string = "gcp"
-	CLOUD_FIREBASE                string = "firebase"
-	SQL_DB_CONNECTION_URI_DEFAULT string = "host=%s port=%s user=%s password=%s dbname=%s application_name='%s' sslmode=%s"
-	VERSION                              = "v0.0.1"
+	ENVIRONMENT_PRODUCTION           string = "production"
+	ENVIRONMENT_SANDBOX              string = "sandbox"
+	ENVIRONMENT_DEVELOPMENT          string = "development"
+	ENVIRONMENT_TEST                 string = "test"
+	APP_TYPE_SERVICE                 string = "service"
+	APP_TYPE_SERVERLESS              string = "serverless"
+	CLOUD_AWS                        string = "aws"
+	CLOUD_AZURE                      string = "azure"
+	CLOUD_GCP                        string = "gcp"
+	CLOUD_FIREBASE                   string = "firebase"
+	SQL_DB_REPLICA_ROUND_ROBIN       string = "round-robin"
+	SQL_DB_REPLICA_LEAST_CONNECTIONS string = "least-connections"
+	SQL_DB_CONNECTION_URI_DEFAULT    string = "host=%s port=%s user=%s password=%s dbname=%s application_name='%s' sslmode=%s"
+	VERSION                                 = "v0.0.1"
 
 	// Errors
-	error_enviroment_not_configured                 string = "environment is not configured. Set production, sandbox, development or test"
-	error_app_name_not_configured                   string = "app name is not configured"
-	error_app_type_not_configured                   string = "app type is not configured. Set service or serverless"
-	error_cloud_not_configured                      string = "cloud is not configured. Set aws, azure, gcp or firebase"
-	error_production_required_params_not_configured string = "production required params not configured. Set NEW_RELIC_LICENSE"
-	error_integer_parse                             string = "could not parse %s, permitted int value, got %v: %w"
-	error_boolean_parse                             string = "could not parse %s, permitted 'true' or 'false', got %v: %w"
+	error_enviroment_not_configured                  string = "environment is not configured. Set production, sandbox, development or test"
+	error_app_name_not_configured                    string = "app name is not configured"
+	error_app_type_not_configured                    string = "app type is not configured. Set service or serverless"
+	error_cloud_not_configured                       string = "cloud is not configured. Set aws, azure, gcp or firebase"
+	error_sql_db_replica_strategy_not_configured     string = "sql db replica strategy is not configured. Set round-robin or least-connections"
+	error_environment_required_params_not_configured string = "%s required params not configured. Set %s"
+	error_integer_parse                              string = "could not parse %s, permitted int value, got %v: %w"
+	error_boolean_parse                              string = "could not parse %s, permitted 'true' or 'false', got %v: %w"
 )
 
 var (
@@ -93,36 +107,57 @@ var (
 	SQL_DB_MIGRATION      = false
 	SQL_DB_MAX_OPEN_CONNS = 10
 	SQL_DB_MAX_IDLE_CONNS = 3
+	SQL_DB_DATASOURCES    = map[string]SQLDatasource{}
+
+	SQL_DB_STRICT_COLUMN_MAPPING = false
+
+	SQL_DB_SLOW_QUERY_THRESHOLD_MS = 0 // disabled
+	SQL_DB_QUERY_METRICS           = false
+	SQL_DB_STATEMENT_CACHE_SIZE    = 100 // 0 disables the cache
+
+	SQL_DB_REPLICA_CONNECTION_URIS      = []string{}
+	SQL_DB_REPLICA_STRATEGY             = SQL_DB_REPLICA_ROUND_ROBIN
+	SQL_DB_REPLICA_HEALTH_CHECK_SECONDS = 10
 
 	CACHE_URI      = ""
 	CACHE_PASSWORD = ""
+
+	MESSAGING_OUTBOX_ENABLED          = false
+	MESSAGING_OUTBOX_INTERVAL_SECONDS = 1
+	MESSAGING_OUTBOX_BATCH_SIZE       = 100
 )
 
+// environmentRequiredParams maps each environment to the groups of params required on it.
+// At least one param of each group must be configured.
+var environmentRequiredParams = map[string][][]string{
+	ENVIRONMENT_PRODUCTION: {{ENV_NEW_RELIC_LICENSE, ENV_OTEL_EXPORTER_OTLP_ENDPOINT}},
+}
+
 // Load loads and validates all environment variables. It's used in app initialization.
+// All missing or invalid variables are reported together in a single error.
 func Load() error {
-	godotenv.Load()
+	errs := []error{loadEnvFiles()}
 
 	ENVIRONMENT = os.Getenv(ENV_ENVIRONMENT)
 	if !slices.Contains([]string{ENVIRONMENT_PRODUCTION, ENVIRONMENT_SANDBOX, ENVIRONMENT_DEVELOPMENT, ENVIRONMENT_TEST}, ENVIRONMENT) {
-		return errors.New(error_enviroment_not_configured)
+		errs = append(errs, errors.New(error_enviroment_not_configured))
 	}
 
 	APP_NAME = os.Getenv(ENV_APP_NAME)
 	if APP_NAME == "" {
-		return errors.New(error_app_name_not_configured)
+		errs = append(errs, errors.New(error_app_name_not_configured))
 	}
 
 	APP_TYPE = os.Getenv(ENV_APP_TYPE)
 	if !slices.Contains([]string{APP_TYPE_SERVICE, APP_TYPE_SERVERLESS}, APP_TYPE) {
-		return errors.New(error_app_type_not_configured)
+		errs = append(errs, errors.New(error_app_type_not_configured))
 	}
 
 	CLOUD = os.Getenv(ENV_CLOUD)
 	if !slices.Contains([]string{CLOUD_AWS, CLOUD_AZURE, CLOUD_GCP, CLOUD_FIREBASE}, CLOUD) {
-		return errors.New(error_cloud_not_configured)
+		errs = append(errs, errors.New(error_cloud_not_configured))
 	}
 
-	NEW_RELIC_LICENSE = os.Getenv(ENV_NEW_RELIC_LICENSE)
 	OTEL_EXPORTER_OTLP_ENDPOINT = os.Getenv(ENV_OTEL_EXPORTER_OTLP_ENDPOINT)
 	OTEL_EXPORTER_OTLP_HEADERS = os.Getenv(ENV_OTEL_EXPORTER_OTLP_HEADERS)
 
@@ -130,58 +165,97 @@ func Load() error {
 		LOG_LEVEL = logLevel
 	}
 
-	if err := convertIntEnv(&PORT, ENV_PORT); err != nil {
-		return err
-	}
-
-	if err := convertIntEnvWithDefault(&WAIT_GROUP_TIMEOUT_SECONDS, "WAIT_GROUP_TIMEOUT_SECONDS", WAIT_GROUP_TIMEOUT_SECONDS); err != nil {
-		return err
+	errs = append(errs,
+		convertIntEnv(&PORT, ENV_PORT),
+		convertIntEnvWithDefault(&WAIT_GROUP_TIMEOUT_SECONDS, "WAIT_GROUP_TIMEOUT_SECONDS", WAIT_GROUP_TIMEOUT_SECONDS),
+		convertIntEnv(&SQL_DB_MAX_OPEN_CONNS, ENV_SQL_DB_MAX_OPEN_CONNS),
+		convertIntEnv(&SQL_DB_MAX_IDLE_CONNS, ENV_SQL_DB_MAX_IDLE_CONNS),
+		convertBoolEnv(&SQL_DB_MIGRATION, ENV_SQL_DB_MIGRATION),
+		convertBoolEnv(&SQL_DB_STRICT_COLUMN_MAPPING, ENV_SQL_DB_STRICT_COLUMN_MAPPING),
+		convertIntEnv(&SQL_DB_SLOW_QUERY_THRESHOLD_MS, ENV_SQL_DB_SLOW_QUERY_THRESHOLD_MS),
+		convertBoolEnv(&SQL_DB_QUERY_METRICS, ENV_SQL_DB_QUERY_METRICS),
+		convertIntEnv(&SQL_DB_STATEMENT_CACHE_SIZE, ENV_SQL_DB_STATEMENT_CACHE_SIZE),
+		convertBoolEnv(&CLOUD_DISABLE_SSL, ENV_CLOUD_DISABLE_SSL),
+		convertIntEnv(&SQL_DB_REPLICA_HEALTH_CHECK_SECONDS, ENV_SQL_DB_REPLICA_HEALTH_CHECK_SECONDS),
+		convertBoolEnv(&MESSAGING_OUTBOX_ENABLED, ENV_MESSAGING_OUTBOX_ENABLED),
+		convertIntEnv(&MESSAGING_OUTBOX_INTERVAL_SECONDS, ENV_MESSAGING_OUTBOX_INTERVAL_SECONDS),
+		convertIntEnv(&MESSAGING_OUTBOX_BATCH_SIZE, ENV_MESSAGING_OUTBOX_BATCH_SIZE),
+	)
+
+	SQL_DB_REPLICA_STRATEGY = SQL_DB_REPLICA_ROUND_ROBIN
+	if strategy := os.Getenv(ENV_SQL_DB_REPLICA_STRATEGY); strategy != "" {
+		SQL_DB_REPLICA_STRATEGY = strategy
 	}
-
-	if err := convertIntEnv(&SQL_DB_MAX_OPEN_CONNS, ENV_SQL_DB_MAX_OPEN_CONNS); err != nil {
-		return err
-	}
-
-	if err := convertIntEnv(&SQL_DB_MAX_IDLE_CONNS, ENV_SQL_DB_MAX_IDLE_CONNS); err != nil {
-		return err
-	}
-
-	if err := convertBoolEnv(&SQL_DB_MIGRATION, ENV_SQL_DB_MIGRATION); err != nil {
-		return err
-	}
-
-	if err := convertBoolEnv(&CLOUD_DISABLE_SSL, ENV_CLOUD_DISABLE_SSL); err != nil {
-		return err
+	if !slices.Contains([]string{SQL_DB_REPLICA_ROUND_ROBIN, SQL_DB_REPLICA_LEAST_CONNECTIONS}, SQL_DB_REPLICA_STRATEGY) {
+		errs = append(errs, errors.New(error_sql_db_replica_strategy_not_configured))
 	}
 
 	CLOUD_HOST = os.Getenv(ENV_CLOUD_HOST)
 	CLOUD_REGION = os.Getenv(ENV_CLOUD_REGION)
-	CLOUD_SECRET = os.Getenv(ENV_CLOUD_SECRET)
-	CLOUD_TOKEN = os.Getenv(ENV_CLOUD_TOKEN)
-
 	CACHE_URI = os.Getenv(ENV_CACHE_URI)
-	CACHE_PASSWORD = os.Getenv(ENV_CACHE_PASSWORD)
-
 	SQL_DB_NAME = os.Getenv(ENV_SQL_DB_NAME)
+
+	// secrets are resolved after the cloud settings, because cloud resolvers depend on them
+	var sqlDBPassword string
+	errs = append(errs,
+		loadSecretEnv(&CLOUD_SECRET, ENV_CLOUD_SECRET),
+		loadSecretEnv(&CLOUD_TOKEN, ENV_CLOUD_TOKEN),
+		loadSecretEnv(&NEW_RELIC_LICENSE, ENV_NEW_RELIC_LICENSE),
+		loadSecretEnv(&CACHE_PASSWORD, ENV_CACHE_PASSWORD),
+		loadSecretEnv(&sqlDBPassword, ENV_SQL_DB_PASSWORD),
+	)
+
 	SQL_DB_CONNECTION_URI = fmt.Sprintf(SQL_DB_CONNECTION_URI_DEFAULT,
 		os.Getenv(ENV_SQL_DB_HOST),
 		os.Getenv(ENV_SQL_DB_PORT),
 		os.Getenv(ENV_SQL_DB_USER),
-		os.Getenv(ENV_SQL_DB_PASSWORD),
+		sqlDBPassword,
 		SQL_DB_NAME,
 		APP_NAME,
 		os.Getenv(ENV_SQL_DB_SSL_MODE))
+	SQL_DB_REPLICA_CONNECTION_URIS = replicaConnectionURIs(
+		strings.Split(os.Getenv(ENV_SQL_DB_REPLICA_HOSTS), ","),
+		os.Getenv(ENV_SQL_DB_PORT),
+		os.Getenv(ENV_SQL_DB_USER),
+		sqlDBPassword,
+		SQL_DB_NAME,
+		os.Getenv(ENV_SQL_DB_SSL_MODE))
 
-	return nil
+	errs = append(errs, loadSQLDatasources()...)
+	errs = append(errs, validateEnvironmentRequiredParams()...)
+	return errors.Join(errs...)
+}
+
+// RequireEnvironmentParams adds a group of params required on the environment.
+// At least one of the params must be configured, otherwise Load returns an error.
+func RequireEnvironmentParams(environment string, params ...string) {
+	environmentRequiredParams[environment] = append(environmentRequiredParams[environment], params)
+}
+
+// validateEnvironmentRequiredParams checks if the required params of the current environment are configured.
+func validateEnvironmentRequiredParams() (errs []error) {
+	for _, params := range environmentRequiredParams[ENVIRONMENT] {
+		if !slices.ContainsFunc(params, func(param string) bool { return os.Getenv(param) != "" }) {
+			errs = append(errs, fmt.Errorf(error_environment_required_params_not_configured, ENVIRONMENT, strings.Join(params, " or ")))
+		}
+	}
+	return errs
+}
+
+// loadEnvFiles loads the .env and profile files values into the environment variables not yet configured.
+func loadEnvFiles() error {
+	_ = godotenv.Load()
+	return loadProfileFiles()
 }
 
 // convertBoolEnv loads the value of an environment variable, converts it to boolean and insert the result into a pointer.
 func convertBoolEnv(env *bool, envName string) error {
 	if envString := os.Getenv(envName); envString != "" {
-		var err error
-		if *env, err = strconv.ParseBool(envString); err != nil {
+		value, err := strconv.ParseBool(envString)
+		if err != nil {
 			return fmt.Errorf(error_boolean_parse, envName, envString, err)
 		}
+		*env = value
 	}
 	return nil
 }
@@ -189,10 +263,11 @@ func convertBoolEnv(env *bool, envName string) error {
 // convertIntEnv loads the value of an environment variable, converts it to interger and insert the result into a pointer.
 func convertIntEnv(env *int, envName string) error {
 	if envString := os.Getenv(envName); envString != "" {
-		var err error
-		if *env, err = strconv.Atoi(envString); err != nil {
+		value, err := strconv.Atoi(envString)
+		if err != nil {
 			return fmt.Errorf(error_integer_parse, envName, envString, err)
 		}
+		*env = value
 	}
 	return nil
 }
@@ -200,10 +275,11 @@ func convertIntEnv(env *int, envName string) error {
 // convertIntEnvWithDefault loads the value of an environment variable, converts it to interger and insert the result into a pointer.
 func convertIntEnvWithDefault(env *int, envName string, fallback int) error {
 	envString := getEnvWithDefault(envName, fallback)
-	var err error
-	if *env, err = strconv.Atoi(envString); err != nil {
+	value, err := strconv.Atoi(envString)
+	if err != nil {
 		return fmt.Errorf(error_integer_parse, envName, envString, err)
 	}
+	*env = value
 	return nil
 }
 
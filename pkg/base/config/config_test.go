@@ -34,7 +34,7 @@ const (
 
 func TestEnvironmentProfiles(t *testing.T) {
 	t.Run("Should return error when enviroment is not configured", func(t *testing.T) {
-		assert.EqualError(t, Load(), error_enviroment_not_configured)
+		assert.ErrorContains(t, Load(), error_enviroment_not_configured)
 	})
 
 	t.Run("Should return error when enviroment contains a invalid value", func(t *testing.T) {
@@ -42,7 +42,7 @@ func TestEnvironmentProfiles(t *testing.T) {
 
 		err := Load()
 		assert.Equal(t, invalid_value, ENVIRONMENT)
-		assert.EqualError(t, err, error_enviroment_not_configured)
+		assert.ErrorContains(t, err, error_enviroment_not_configured)
 	})
 
 	t.Run("Should configure with production environment", func(t *testing.T) {
@@ -106,7 +106,7 @@ func TestAppName(t *testing.T) {
 
 		err := Load()
 		assert.Equal(t, ENVIRONMENT_PRODUCTION, ENVIRONMENT)
-		assert.EqualError(t, err, error_app_name_not_configured)
+		assert.ErrorContains(t, err, error_app_name_not_configured)
 	})
 
 	t.Run("Should return app name", func(t *testing.T) {
@@ -126,7 +126,7 @@ func TestAppType(t *testing.T) {
 		err := Load()
 		assert.Equal(t, ENVIRONMENT_PRODUCTION, ENVIRONMENT)
 		assert.Equal(t, app_name_value, APP_NAME)
-		assert.EqualError(t, err, error_app_type_not_configured)
+		assert.ErrorContains(t, err, error_app_type_not_configured)
 	})
 
 	t.Run("Should return error when app_type contains a invalid value", func(t *testing.T) {
@@ -136,7 +136,7 @@ func TestAppType(t *testing.T) {
 		assert.Equal(t, ENVIRONMENT_PRODUCTION, ENVIRONMENT)
 		assert.Equal(t, app_name_value, APP_NAME)
 		assert.Equal(t, invalid_value, APP_TYPE)
-		assert.EqualError(t, err, error_app_type_not_configured)
+		assert.ErrorContains(t, err, error_app_type_not_configured)
 	})
 
 	t.Run("Should return service app type", func(t *testing.T) {
@@ -164,7 +164,7 @@ func TestCloud(t *testing.T) {
 	assert.NoError(t, os.Setenv(ENV_APP_TYPE, APP_TYPE_SERVERLESS))
 
 	t.Run("Should return error when cloud is not configured", func(t *testing.T) {
-		assert.EqualError(t, Load(), error_cloud_not_configured)
+		assert.ErrorContains(t, Load(), error_cloud_not_configured)
 	})
 
 	t.Run("Should return error when enviroment contains a invalid value", func(t *testing.T) {
@@ -172,7 +172,7 @@ func TestCloud(t *testing.T) {
 
 		err := Load()
 		assert.Equal(t, invalid_value, CLOUD)
-		assert.EqualError(t, err, error_cloud_not_configured)
+		assert.ErrorContains(t, err, error_cloud_not_configured)
 	})
 
 	t.Run("Should configure with aws environment", func(t *testing.T) {
@@ -233,6 +233,53 @@ func TestNewRelicKey(t *testing.T) {
 	})
 }
 
+func TestEnvironmentRequiredParams(t *testing.T) {
+	loadTestEnvs(t)
+	t.Setenv(ENV_ENVIRONMENT, ENVIRONMENT_PRODUCTION)
+	t.Setenv(ENV_NEW_RELIC_LICENSE, "")
+	t.Setenv(ENV_OTEL_EXPORTER_OTLP_ENDPOINT, "")
+
+	t.Run("Should return error when production required params are not configured", func(t *testing.T) {
+		expected := fmt.Sprintf(error_environment_required_params_not_configured, ENVIRONMENT_PRODUCTION, "NEW_RELIC_LICENSE or OTEL_EXPORTER_OTLP_ENDPOINT")
+
+		assert.EqualError(t, Load(), expected)
+	})
+
+	t.Run("Should load production environment with open telemetry endpoint", func(t *testing.T) {
+		t.Setenv(ENV_OTEL_EXPORTER_OTLP_ENDPOINT, cloud_host_value)
+
+		assert.NoError(t, Load())
+	})
+
+	t.Run("Should return error when custom required params are not configured", func(t *testing.T) {
+		t.Setenv(ENV_ENVIRONMENT, ENVIRONMENT_SANDBOX)
+		RequireEnvironmentParams(ENVIRONMENT_SANDBOX, ENV_CACHE_URI)
+		defer delete(environmentRequiredParams, ENVIRONMENT_SANDBOX)
+
+		assert.EqualError(t, Load(), fmt.Sprintf(error_environment_required_params_not_configured, ENVIRONMENT_SANDBOX, ENV_CACHE_URI))
+	})
+}
+
+func TestLoadWithMultipleErrors(t *testing.T) {
+	t.Setenv(ENV_ENVIRONMENT, invalid_value)
+	t.Setenv(ENV_APP_NAME, "")
+	t.Setenv(ENV_APP_TYPE, invalid_value)
+	t.Setenv(ENV_CLOUD, invalid_value)
+	t.Setenv(ENV_PORT, invalid_value)
+	t.Setenv(ENV_SQL_DB_MIGRATION, invalid_value)
+
+	t.Run("Should return all configuration errors together", func(t *testing.T) {
+		err := Load()
+
+		assert.ErrorContains(t, err, error_enviroment_not_configured)
+		assert.ErrorContains(t, err, error_app_name_not_configured)
+		assert.ErrorContains(t, err, error_app_type_not_configured)
+		assert.ErrorContains(t, err, error_cloud_not_configured)
+		assert.ErrorContains(t, err, fmt.Sprintf("could not parse %s, permitted int value", ENV_PORT))
+		assert.ErrorContains(t, err, fmt.Sprintf("could not parse %s, permitted 'true' or 'false'", ENV_SQL_DB_MIGRATION))
+	})
+}
+
 func TestServerPort(t *testing.T) {
 	loadTestEnvs(t)
 
@@ -317,6 +364,118 @@ func TestSqlDBMigration(t *testing.T) {
 	})
 }
 
+func TestSqlDBStrictColumnMapping(t *testing.T) {
+	loadTestEnvs(t)
+	t.Cleanup(func() { SQL_DB_STRICT_COLUMN_MAPPING = false })
+
+	t.Run("Should return default strict column mapping when environment is empty", func(t *testing.T) {
+		Load()
+		assert.False(t, SQL_DB_STRICT_COLUMN_MAPPING)
+	})
+
+	t.Run("Should return error when strict column mapping is wrong value", func(t *testing.T) {
+		t.Setenv(ENV_SQL_DB_STRICT_COLUMN_MAPPING, invalid_value)
+		assert.ErrorContains(t, Load(), ENV_SQL_DB_STRICT_COLUMN_MAPPING)
+	})
+
+	t.Run("Should return strict column mapping when environment is not empty", func(t *testing.T) {
+		t.Setenv(ENV_SQL_DB_STRICT_COLUMN_MAPPING, "true")
+
+		Load()
+		assert.True(t, SQL_DB_STRICT_COLUMN_MAPPING)
+	})
+}
+
+func TestSqlDBQueryObservability(t *testing.T) {
+	loadTestEnvs(t)
+	t.Cleanup(func() {
+		SQL_DB_SLOW_QUERY_THRESHOLD_MS = 0
+		SQL_DB_QUERY_METRICS = false
+	})
+
+	t.Run("Should return default query observability when environment is empty", func(t *testing.T) {
+		Load()
+		assert.Zero(t, SQL_DB_SLOW_QUERY_THRESHOLD_MS)
+		assert.False(t, SQL_DB_QUERY_METRICS)
+	})
+
+	t.Run("Should return error when query observability is wrong value", func(t *testing.T) {
+		t.Setenv(ENV_SQL_DB_SLOW_QUERY_THRESHOLD_MS, invalid_value)
+		t.Setenv(ENV_SQL_DB_QUERY_METRICS, invalid_value)
+
+		err := Load()
+		assert.ErrorContains(t, err, ENV_SQL_DB_SLOW_QUERY_THRESHOLD_MS)
+		assert.ErrorContains(t, err, ENV_SQL_DB_QUERY_METRICS)
+	})
+
+	t.Run("Should return query observability when environment is not empty", func(t *testing.T) {
+		t.Setenv(ENV_SQL_DB_SLOW_QUERY_THRESHOLD_MS, "200")
+		t.Setenv(ENV_SQL_DB_QUERY_METRICS, "true")
+
+		Load()
+		assert.Equal(t, 200, SQL_DB_SLOW_QUERY_THRESHOLD_MS)
+		assert.True(t, SQL_DB_QUERY_METRICS)
+	})
+}
+
+func TestSqlDBStatementCacheSize(t *testing.T) {
+	loadTestEnvs(t)
+	t.Cleanup(func() { SQL_DB_STATEMENT_CACHE_SIZE = 100 })
+
+	t.Run("Should return default statement cache size when environment is empty", func(t *testing.T) {
+		Load()
+		assert.Equal(t, 100, SQL_DB_STATEMENT_CACHE_SIZE)
+	})
+
+	t.Run("Should return error when statement cache size is wrong value", func(t *testing.T) {
+		t.Setenv(ENV_SQL_DB_STATEMENT_CACHE_SIZE, invalid_value)
+		assert.ErrorContains(t, Load(), ENV_SQL_DB_STATEMENT_CACHE_SIZE)
+	})
+
+	t.Run("Should return statement cache size when environment is not empty", func(t *testing.T) {
+		t.Setenv(ENV_SQL_DB_STATEMENT_CACHE_SIZE, "0")
+
+		Load()
+		assert.Zero(t, SQL_DB_STATEMENT_CACHE_SIZE)
+	})
+}
+
+func TestMessagingOutbox(t *testing.T) {
+	loadTestEnvs(t)
+	t.Cleanup(func() {
+		MESSAGING_OUTBOX_ENABLED = false
+		MESSAGING_OUTBOX_INTERVAL_SECONDS = 1
+		MESSAGING_OUTBOX_BATCH_SIZE = 100
+	})
+
+	t.Run("Should return default messaging outbox when environment is empty", func(t *testing.T) {
+		Load()
+		assert.False(t, MESSAGING_OUTBOX_ENABLED)
+		assert.Equal(t, 1, MESSAGING_OUTBOX_INTERVAL_SECONDS)
+		assert.Equal(t, 100, MESSAGING_OUTBOX_BATCH_SIZE)
+	})
+
+	t.Run("Should return error when messaging outbox is wrong value", func(t *testing.T) {
+		t.Setenv(ENV_MESSAGING_OUTBOX_ENABLED, invalid_value)
+		t.Setenv(ENV_MESSAGING_OUTBOX_BATCH_SIZE, invalid_value)
+
+		err := Load()
+		assert.ErrorContains(t, err, ENV_MESSAGING_OUTBOX_ENABLED)
+		assert.ErrorContains(t, err, ENV_MESSAGING_OUTBOX_BATCH_SIZE)
+	})
+
+	t.Run("Should return messaging outbox when environment is not empty", func(t *testing.T) {
+		t.Setenv(ENV_MESSAGING_OUTBOX_ENABLED, "true")
+		t.Setenv(ENV_MESSAGING_OUTBOX_INTERVAL_SECONDS, "5")
+		t.Setenv(ENV_MESSAGING_OUTBOX_BATCH_SIZE, "10")
+
+		Load()
+		assert.True(t, MESSAGING_OUTBOX_ENABLED)
+		assert.Equal(t, 5, MESSAGING_OUTBOX_INTERVAL_SECONDS)
+		assert.Equal(t, 10, MESSAGING_OUTBOX_BATCH_SIZE)
+	})
+}
+
 func TestCloudDisableSsl(t *testing.T) {
 	loadTestEnvs(t)
 
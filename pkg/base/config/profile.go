@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ENV_PROFILE_PATH string = "PROFILE_PATH"
+
+	profileFileName        string = "application"
+	profileFileExtension   string = ".yaml"
+	profileDefaultPath     string = "."
+	profileKeySeparator    string = "_"
+	profileValuesSeparator string = ","
+
+	error_profile_file_read string = "could not read profile file %s: %w"
+)
+
+// profileKeyReplacer normalizes the yaml keys into environment variable names.
+var profileKeyReplacer = strings.NewReplacer("-", profileKeySeparator, ".", profileKeySeparator)
+
+// loadProfileFiles loads the application.yaml and application-<ENVIRONMENT>.yaml files into the environment variables not yet configured.
+//
+// The environment file values override the application file values, and the environment variables override both.
+// Nested keys are joined with underscore and uppercased, so `sql: {db: {host: x}}` is loaded as SQL_DB_HOST.
+func loadProfileFiles() error {
+	path := os.Getenv(ENV_PROFILE_PATH)
+	if path == "" {
+		path = profileDefaultPath
+	}
+
+	base, err := readProfileFile(filepath.Join(path, profileFileName+profileFileExtension))
+	if err != nil {
+		return err
+	}
+
+	environment, exists := os.LookupEnv(ENV_ENVIRONMENT)
+	if !exists || environment == "" {
+		environment = base[ENV_ENVIRONMENT]
+	}
+
+	profile := map[string]string{}
+	if environment != "" {
+		if profile, err = readProfileFile(filepath.Join(path, profileFileName+"-"+environment+profileFileExtension)); err != nil {
+			return err
+		}
+	}
+
+	for _, values := range []map[string]string{profile, base} {
+		for key, value := range values {
+			if _, exists := os.LookupEnv(key); !exists {
+				_ = os.Setenv(key, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// readProfileFile reads a yaml profile file into a flat map of environment variables. Missing files return an empty map.
+func readProfileFile(fileName string) (map[string]string, error) {
+	values := map[string]string{}
+
+	content, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	} else if err != nil {
+		return nil, fmt.Errorf(error_profile_file_read, fileName, err)
+	}
+
+	var document map[string]any
+	if err = yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf(error_profile_file_read, fileName, err)
+	}
+
+	flattenProfile(values, "", document)
+	return values, nil
+}
+
+// flattenProfile converts the nested yaml document into environment variable names and values.
+func flattenProfile(values map[string]string, prefix string, document map[string]any) {
+	for key, value := range document {
+		name := strings.ToUpper(profileKeyReplacer.Replace(prefix + key))
+
+		switch v := value.(type) {
+		case map[string]any:
+			flattenProfile(values, name+profileKeySeparator, v)
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[name] = strings.Join(items, profileValuesSeparator)
+		case nil:
+			values[name] = ""
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+}
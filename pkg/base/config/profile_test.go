@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	profileApplicationContent = `
+environment: test
+app-name: profile app name
+sql:
+  db:
+    host: application-host
+    port: 5432
+    max_open_conns: 15
+profile:
+  tags:
+    - a
+    - b
+`
+	profileTestContent = `
+sql:
+  db:
+    host: test-host
+`
+)
+
+func TestProfileFiles(t *testing.T) {
+	profilePath := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(profilePath, "application.yaml"), []byte(profileApplicationContent), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(profilePath, "application-test.yaml"), []byte(profileTestContent), 0o600))
+
+	t.Run("Should load profile files under the environment variables", func(t *testing.T) {
+		unsetTestEnvs(t, ENV_ENVIRONMENT, ENV_APP_NAME, ENV_SQL_DB_HOST, ENV_SQL_DB_PORT, ENV_SQL_DB_MAX_OPEN_CONNS, "PROFILE_TAGS")
+		t.Setenv(ENV_PROFILE_PATH, profilePath)
+		t.Setenv(ENV_SQL_DB_PORT, sql_db_port_value)
+		t.Setenv(ENV_APP_TYPE, APP_TYPE_SERVICE)
+		t.Setenv(ENV_CLOUD, CLOUD_GCP)
+
+		assert.NoError(t, Load())
+		assert.Equal(t, ENVIRONMENT_TEST, ENVIRONMENT)
+		assert.Equal(t, "profile app name", APP_NAME)
+		assert.Equal(t, 15, SQL_DB_MAX_OPEN_CONNS)
+		assert.Equal(t, "test-host", os.Getenv(ENV_SQL_DB_HOST))
+		assert.Equal(t, sql_db_port_value, os.Getenv(ENV_SQL_DB_PORT))
+		assert.Equal(t, "a,b", os.Getenv("PROFILE_TAGS"))
+	})
+
+	t.Run("Should not load environment profile file of other environment", func(t *testing.T) {
+		unsetTestEnvs(t, ENV_SQL_DB_HOST, ENV_SQL_DB_PORT, ENV_SQL_DB_MAX_OPEN_CONNS, "PROFILE_TAGS")
+		loadTestEnvs(t)
+		t.Setenv(ENV_PROFILE_PATH, profilePath)
+		t.Setenv(ENV_ENVIRONMENT, ENVIRONMENT_DEVELOPMENT)
+
+		assert.NoError(t, Load())
+		assert.Equal(t, "application-host", os.Getenv(ENV_SQL_DB_HOST))
+	})
+
+	t.Run("Should ignore profile files when they do not exist", func(t *testing.T) {
+		loadTestEnvs(t)
+		t.Setenv(ENV_PROFILE_PATH, t.TempDir())
+
+		assert.NoError(t, Load())
+	})
+
+	t.Run("Should return error when profile file is invalid", func(t *testing.T) {
+		invalidPath := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(invalidPath, "application.yaml"), []byte("invalid: [yaml"), 0o600))
+		loadTestEnvs(t)
+		t.Setenv(ENV_PROFILE_PATH, invalidPath)
+
+		assert.ErrorContains(t, Load(), "could not read profile file")
+	})
+}
+
+func unsetTestEnvs(t *testing.T, envs ...string) {
+	for _, env := range envs {
+		t.Setenv(env, "")
+		assert.NoError(t, os.Unsetenv(env))
+	}
+}
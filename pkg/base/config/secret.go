@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	SECRET_SCHEME_FILE string = "file"
+
+	error_secret_resolve string = "could not resolve secret of %s: %w"
+)
+
+// SecretResolver is the contract to resolve a secret reference (ex: file:///run/secrets/db) into its value.
+type SecretResolver interface {
+	Resolve(ctx context.Context, reference *url.URL) (string, error)
+}
+
+// SecretResolverFunc is an adapter to allow the use of ordinary functions as SecretResolver.
+type SecretResolverFunc func(ctx context.Context, reference *url.URL) (string, error)
+
+// Resolve calls f(ctx, reference).
+func (f SecretResolverFunc) Resolve(ctx context.Context, reference *url.URL) (string, error) {
+	return f(ctx, reference)
+}
+
+var (
+	secretResolversMutex sync.RWMutex
+	secretResolvers      = map[string]SecretResolver{
+		SECRET_SCHEME_FILE: SecretResolverFunc(resolveFileSecret),
+	}
+)
+
+// RegisterSecretResolver registers a resolver for the secret references with the scheme.
+func RegisterSecretResolver(scheme string, resolver SecretResolver) {
+	secretResolversMutex.Lock()
+	defer secretResolversMutex.Unlock()
+	secretResolvers[scheme] = resolver
+}
+
+// ResolveSecret returns the secret value of a reference. Values without a registered scheme are returned as they are.
+func ResolveSecret(ctx context.Context, value string) (string, error) {
+	reference, err := url.Parse(value)
+	if err != nil || reference.Scheme == "" {
+		return value, nil
+	}
+
+	secretResolversMutex.RLock()
+	resolver, exists := secretResolvers[reference.Scheme]
+	secretResolversMutex.RUnlock()
+	if !exists {
+		return value, nil
+	}
+
+	return resolver.Resolve(ctx, reference)
+}
+
+// loadSecretEnv loads the value of an environment variable, resolves the secret reference if there is one and insert the result into a pointer.
+func loadSecretEnv(env *string, envName string) error {
+	value, err := ResolveSecret(context.Background(), os.Getenv(envName))
+	if err != nil {
+		return fmt.Errorf(error_secret_resolve, envName, err)
+	}
+	*env = value
+	return nil
+}
+
+// resolveFileSecret reads the secret from the file of the reference (ex: file:///run/secrets/db).
+func resolveFileSecret(_ context.Context, reference *url.URL) (string, error) {
+	content, err := os.ReadFile(reference.Host + reference.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
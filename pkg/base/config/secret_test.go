@@ -0,0 +1,88 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSecret(t *testing.T) {
+	ctx := context.Background()
+	secretFile := filepath.Join(t.TempDir(), "db-password")
+	assert.NoError(t, os.WriteFile(secretFile, []byte(sql_db_password_value+"\n"), 0o600))
+
+	t.Run("Should return value when it is not a secret reference", func(t *testing.T) {
+		for _, value := range []string{"", sql_db_password_value, cloud_host_value, "unknown://secret"} {
+			result, err := ResolveSecret(ctx, value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, value, result)
+		}
+	})
+
+	t.Run("Should resolve file secret reference", func(t *testing.T) {
+		result, err := ResolveSecret(ctx, "file://"+secretFile)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sql_db_password_value, result)
+	})
+
+	t.Run("Should return error when file secret does not exist", func(t *testing.T) {
+		result, err := ResolveSecret(ctx, "file:///not/found/secret")
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Should resolve secret reference with custom resolver", func(t *testing.T) {
+		RegisterSecretResolver("vault", SecretResolverFunc(func(_ context.Context, reference *url.URL) (string, error) {
+			return reference.Host + reference.Path, nil
+		}))
+		defer delete(secretResolvers, "vault")
+
+		result, err := ResolveSecret(ctx, "vault://database/password")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "database/password", result)
+	})
+
+	t.Run("Should load configuration resolving secret references", func(t *testing.T) {
+		loadTestEnvs(t)
+		t.Setenv(ENV_SQL_DB_PASSWORD, "file://"+secretFile)
+		t.Setenv(ENV_CACHE_PASSWORD, "file://"+secretFile)
+
+		assert.NoError(t, Load())
+		assert.Equal(t, sql_db_password_value, CACHE_PASSWORD)
+		assert.Contains(t, SQL_DB_CONNECTION_URI, fmt.Sprintf("password=%s ", sql_db_password_value))
+	})
+
+	t.Run("Should return error when secret reference could not be resolved", func(t *testing.T) {
+		expectedErr := errors.New("secret not found")
+		RegisterSecretResolver("vault", SecretResolverFunc(func(context.Context, *url.URL) (string, error) {
+			return "", expectedErr
+		}))
+		defer delete(secretResolvers, "vault")
+		loadTestEnvs(t)
+		t.Setenv(ENV_CLOUD_SECRET, "vault://cloud/secret")
+
+		err := Load()
+		assert.ErrorIs(t, err, expectedErr)
+		assert.ErrorContains(t, err, fmt.Sprintf("could not resolve secret of %s", ENV_CLOUD_SECRET))
+	})
+
+	t.Run("Should bind secret fields", func(t *testing.T) {
+		t.Setenv("BIND_SECRET", "file://"+secretFile)
+
+		var target struct {
+			Secret string `env:"BIND_SECRET" secret:"true"`
+		}
+		assert.NoError(t, Bind(&target))
+		assert.Equal(t, sql_db_password_value, target.Secret)
+	})
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	sqlDatasourcePrefix string = "SQL_DB_%s_"
+)
+
+// sqlDatasourceHostPattern matches the host env variable of the named datasources, ex: SQL_DB_REPORTING_HOST.
+var sqlDatasourceHostPattern = regexp.MustCompile(`^SQL_DB_([A-Z0-9_]+)_HOST$`)
+
+// SQLDatasource is the configuration of a named sql database, loaded from the env variables with prefix SQL_DB_<NAME>_.
+type SQLDatasource struct {
+	Name               string
+	DBName             string   `env:"NAME"`
+	Host               string   `env:"HOST" required:"true"`
+	Port               string   `env:"PORT" default:"5432"`
+	User               string   `env:"USER"`
+	Password           string   `env:"PASSWORD" secret:"true"`
+	SSLMode            string   `env:"SSL_MODE" default:"disable"`
+	MaxOpenConns       int      `env:"MAX_OPEN_CONNS" default:"10"`
+	MaxIdleConns       int      `env:"MAX_IDLE_CONNS" default:"3"`
+	Migration          bool     `env:"MIGRATION"`
+	MigrationSourceURL string   `env:"MIGRATION_SOURCE_URL"`
+	ReplicaHosts       []string `env:"REPLICA_HOSTS"`
+}
+
+// ConnectionURI returns the connection uri of the datasource.
+func (d SQLDatasource) ConnectionURI() string {
+	return fmt.Sprintf(SQL_DB_CONNECTION_URI_DEFAULT, d.Host, d.Port, d.User, d.Password, d.DBName, APP_NAME, d.SSLMode)
+}
+
+// ReplicaConnectionURIs returns the connection uris of the datasource read replicas.
+func (d SQLDatasource) ReplicaConnectionURIs() []string {
+	return replicaConnectionURIs(d.ReplicaHosts, d.Port, d.User, d.Password, d.DBName, d.SSLMode)
+}
+
+// replicaConnectionURIs returns the connection uris of the read replica hosts (host or host:port), using the primary database settings.
+func replicaConnectionURIs(hosts []string, port, user, password, dbName, sslMode string) []string {
+	uris := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if host = strings.TrimSpace(host); host == "" {
+			continue
+		}
+
+		replicaHost, replicaPort, found := strings.Cut(host, ":")
+		if !found {
+			replicaPort = port
+		}
+		uris = append(uris, fmt.Sprintf(SQL_DB_CONNECTION_URI_DEFAULT, replicaHost, replicaPort, user, password, dbName, APP_NAME, sslMode))
+	}
+	return uris
+}
+
+// loadSQLDatasources loads all named sql datasources declared by the SQL_DB_<NAME>_HOST env variables.
+func loadSQLDatasources() (errs []error) {
+	SQL_DB_DATASOURCES = map[string]SQLDatasource{}
+
+	for _, env := range os.Environ() {
+		key, _, _ := strings.Cut(env, "=")
+		matches := sqlDatasourceHostPattern.FindStringSubmatch(key)
+		if matches == nil {
+			continue
+		}
+
+		datasource := SQLDatasource{Name: matches[1]}
+		if err := bind(fmt.Sprintf(sqlDatasourcePrefix, datasource.Name), &datasource); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		SQL_DB_DATASOURCES[datasource.Name] = datasource
+	}
+
+	return errs
+}
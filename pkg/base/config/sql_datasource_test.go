@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLDatasources(t *testing.T) {
+	loadTestEnvs(t)
+
+	t.Run("Should load without named datasources", func(t *testing.T) {
+		assert.NoError(t, Load())
+		assert.Empty(t, SQL_DB_DATASOURCES)
+	})
+
+	t.Run("Should load named datasources from env prefix", func(t *testing.T) {
+		t.Setenv("SQL_DB_REPORTING_HOST", sql_db_host_value)
+		t.Setenv("SQL_DB_REPORTING_NAME", sql_db_name_value)
+		t.Setenv("SQL_DB_REPORTING_USER", sql_db_user_value)
+		t.Setenv("SQL_DB_REPORTING_PASSWORD", sql_db_password_value)
+		t.Setenv("SQL_DB_REPORTING_MAX_OPEN_CONNS", "20")
+		t.Setenv("SQL_DB_REPORTING_MIGRATION", "true")
+		t.Setenv("SQL_DB_REPORTING_MIGRATION_SOURCE_URL", "./reporting/migrations")
+		t.Setenv("SQL_DB_AUDIT_LOG_HOST", sql_db_host_value)
+
+		assert.NoError(t, Load())
+		assert.Len(t, SQL_DB_DATASOURCES, 2)
+
+		reporting := SQL_DB_DATASOURCES["REPORTING"]
+		assert.Equal(t, "REPORTING", reporting.Name)
+		assert.Equal(t, 20, reporting.MaxOpenConns)
+		assert.Equal(t, 3, reporting.MaxIdleConns)
+		assert.True(t, reporting.Migration)
+		assert.Equal(t, "./reporting/migrations", reporting.MigrationSourceURL)
+		assert.Equal(t, fmt.Sprintf(SQL_DB_CONNECTION_URI_DEFAULT, sql_db_host_value, "5432", sql_db_user_value, sql_db_password_value, sql_db_name_value, app_name_value, "disable"), reporting.ConnectionURI())
+
+		audit := SQL_DB_DATASOURCES["AUDIT_LOG"]
+		assert.Equal(t, "AUDIT_LOG", audit.Name)
+		assert.False(t, audit.Migration)
+	})
+
+	t.Run("Should return error when named datasource contains invalid value", func(t *testing.T) {
+		t.Setenv("SQL_DB_REPORTING_HOST", sql_db_host_value)
+		t.Setenv("SQL_DB_REPORTING_MAX_IDLE_CONNS", invalid_value)
+
+		assert.ErrorContains(t, Load(), "could not parse SQL_DB_REPORTING_MAX_IDLE_CONNS, permitted int value")
+	})
+}
+
+func TestSQLReplicas(t *testing.T) {
+	loadTestEnvs(t)
+	t.Setenv(ENV_SQL_DB_PORT, sql_db_port_value)
+	t.Setenv(ENV_SQL_DB_USER, sql_db_user_value)
+	t.Setenv(ENV_SQL_DB_PASSWORD, sql_db_password_value)
+	t.Setenv(ENV_SQL_DB_NAME, sql_db_name_value)
+	t.Setenv(ENV_SQL_DB_SSL_MODE, sql_db_ssl_mode_value)
+
+	t.Run("Should load without replicas", func(t *testing.T) {
+		assert.NoError(t, Load())
+		assert.Empty(t, SQL_DB_REPLICA_CONNECTION_URIS)
+		assert.Equal(t, SQL_DB_REPLICA_ROUND_ROBIN, SQL_DB_REPLICA_STRATEGY)
+		assert.Equal(t, 10, SQL_DB_REPLICA_HEALTH_CHECK_SECONDS)
+	})
+
+	t.Run("Should load replica connection uris", func(t *testing.T) {
+		t.Setenv(ENV_SQL_DB_REPLICA_HOSTS, "replica-1, replica-2:6432")
+		t.Setenv(ENV_SQL_DB_REPLICA_STRATEGY, SQL_DB_REPLICA_LEAST_CONNECTIONS)
+		t.Setenv(ENV_SQL_DB_REPLICA_HEALTH_CHECK_SECONDS, "5")
+
+		assert.NoError(t, Load())
+		assert.Equal(t, []string{
+			fmt.Sprintf(SQL_DB_CONNECTION_URI_DEFAULT, "replica-1", sql_db_port_value, sql_db_user_value, sql_db_password_value, sql_db_name_value, app_name_value, sql_db_ssl_mode_value),
+			fmt.Sprintf(SQL_DB_CONNECTION_URI_DEFAULT, "replica-2", "6432", sql_db_user_value, sql_db_password_value, sql_db_name_value, app_name_value, sql_db_ssl_mode_value),
+		}, SQL_DB_REPLICA_CONNECTION_URIS)
+		assert.Equal(t, SQL_DB_REPLICA_LEAST_CONNECTIONS, SQL_DB_REPLICA_STRATEGY)
+		assert.Equal(t, 5, SQL_DB_REPLICA_HEALTH_CHECK_SECONDS)
+	})
+
+	t.Run("Should load named datasource replica connection uris", func(t *testing.T) {
+		t.Setenv("SQL_DB_REPORTING_HOST", sql_db_host_value)
+		t.Setenv("SQL_DB_REPORTING_REPLICA_HOSTS", "reporting-replica")
+
+		assert.NoError(t, Load())
+		assert.Equal(t, []string{
+			fmt.Sprintf(SQL_DB_CONNECTION_URI_DEFAULT, "reporting-replica", "5432", "", "", "", app_name_value, "disable"),
+		}, SQL_DB_DATASOURCES["REPORTING"].ReplicaConnectionURIs())
+	})
+
+	t.Run("Should return error when replica strategy is invalid", func(t *testing.T) {
+		t.Setenv(ENV_SQL_DB_REPLICA_STRATEGY, invalid_value)
+
+		assert.ErrorContains(t, Load(), error_sql_db_replica_strategy_not_configured)
+	})
+}
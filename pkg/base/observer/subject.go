@@ -10,6 +10,7 @@ import (
 
 type subject interface {
 	attach(observer Observer)
+	attachFirst(observer Observer)
 	notify()
 }
 
@@ -35,6 +36,12 @@ func Attach(o Observer) {
 	services.attach(o)
 }
 
+// AttachFirst the subject on services observer to be closed before the observers already attached,
+// ex: a background worker that uses the database must stop before the database is closed
+func AttachFirst(o Observer) {
+	services.attachFirst(o)
+}
+
 type service struct {
 	observers []Observer
 }
@@ -43,6 +50,10 @@ func (s *service) attach(observer Observer) {
 	s.observers = append(s.observers, observer)
 }
 
+func (s *service) attachFirst(observer Observer) {
+	s.observers = append([]Observer{observer}, s.observers...)
+}
+
 func (s service) notify() {
 	for _, observer := range s.observers {
 		observer.Close()
@@ -25,3 +25,23 @@ func TestSubjectNotify(t *testing.T) {
 	services.notify()
 	assert.True(t, o.closed)
 }
+
+type orderedObserverTest struct {
+	name  string
+	order *[]string
+}
+
+func (o orderedObserverTest) Close() {
+	*o.order = append(*o.order, o.name)
+}
+
+func TestSubjectNotifyOrder(t *testing.T) {
+	var order []string
+	Initialize()
+	Attach(orderedObserverTest{"database", &order})
+	Attach(orderedObserverTest{"messaging", &order})
+	AttachFirst(orderedObserverTest{"worker", &order})
+
+	services.notify()
+	assert.Equal(t, []string{"worker", "database", "messaging"}, order)
+}
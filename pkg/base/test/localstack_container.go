@@ -46,7 +46,7 @@ func newLocalstackContainer(ctx context.Context, configPath string) *LocalstackC
 		Name:         fmt.Sprintf("colibri-project-test-localstack-%s", uuid.New().String()),
 		Env: map[string]string{
 			"DEBUG":    "1",
-			"SERVICES": "sns,sqs,s3,dynamodb",
+			"SERVICES": "sns,sqs,s3,dynamodb,secretsmanager",
 		},
 		HostConfigModifier: func(hostConfig *container.HostConfig) {
 			hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
@@ -0,0 +1,121 @@
+package transaction
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
+)
+
+// HooksContextKey is the type of the context key for the transaction hooks.
+type HooksContextKey string
+
+const (
+	HooksContext HooksContextKey = "TransactionHooksContext"
+
+	hookPanicErrorMsg string = "panic recovering transaction hook: %v\n%s"
+)
+
+// Hooks holds the callbacks registered to run after the commit or the rollback of a transaction.
+type Hooks struct {
+	mu       sync.Mutex
+	commit   []func(ctx context.Context)
+	rollback []func(ctx context.Context)
+}
+
+// NewHooks creates a new Hooks and returns a context with it, so the callbacks registered with OnCommit and OnRollback
+// are stored in it. It's used by the Transaction implementations.
+//
+// ctx: the context of the transaction.
+// Returns the context with the hooks and the hooks.
+func NewHooks(ctx context.Context) (context.Context, *Hooks) {
+	hooks := &Hooks{}
+	return context.WithValue(ctx, HooksContext, hooks), hooks
+}
+
+// OnCommit registers a callback to run after the commit of the transaction in the context.
+// When there is no transaction in the context, the callback runs immediately.
+//
+// ctx: the context of the transaction.
+// fn: the callback.
+func OnCommit(ctx context.Context, fn func(ctx context.Context)) {
+	hooks, ok := ctx.Value(HooksContext).(*Hooks)
+	if !ok {
+		runHook(ctx, fn)
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.commit = append(hooks.commit, fn)
+}
+
+// OnRollback registers a callback to run after the rollback of the transaction in the context.
+// When there is no transaction in the context, the callback is ignored.
+//
+// ctx: the context of the transaction.
+// fn: the callback.
+func OnRollback(ctx context.Context, fn func(ctx context.Context)) {
+	hooks, ok := ctx.Value(HooksContext).(*Hooks)
+	if !ok {
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.rollback = append(hooks.rollback, fn)
+}
+
+// RunCommit runs the commit callbacks in the order they were registered. A panic in a callback is logged
+// and does not stop the others.
+//
+// ctx: the context to run the callbacks, without the finished transaction.
+func (h *Hooks) RunCommit(ctx context.Context) {
+	for _, fn := range h.take(&h.commit) {
+		runHook(ctx, fn)
+	}
+}
+
+// RunRollback runs the rollback callbacks in the order they were registered. A panic in a callback is logged
+// and does not stop the others.
+//
+// ctx: the context to run the callbacks, without the finished transaction.
+func (h *Hooks) RunRollback(ctx context.Context) {
+	for _, fn := range h.take(&h.rollback) {
+		runHook(ctx, fn)
+	}
+}
+
+// Merge moves the callbacks of a nested transaction into h, so they run with the outer transaction.
+//
+// nested: the hooks of the nested transaction.
+func (h *Hooks) Merge(nested *Hooks) {
+	commit, rollback := nested.take(&nested.commit), nested.take(&nested.rollback)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.commit = append(h.commit, commit...)
+	h.rollback = append(h.rollback, rollback...)
+}
+
+// take removes and returns the callbacks of the list, so each callback runs only once.
+func (h *Hooks) take(list *[]func(ctx context.Context)) []func(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fns := *list
+	*list = nil
+	return fns
+}
+
+// runHook runs the callback recovering and logging a panic.
+func runHook(ctx context.Context, fn func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Error(hookPanicErrorMsg, r, string(debug.Stack()))
+		}
+	}()
+
+	fn(ctx)
+}
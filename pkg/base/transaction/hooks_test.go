@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHooks(t *testing.T) {
+	t.Run("Should run commit callback immediately without transaction", func(t *testing.T) {
+		called := false
+
+		OnCommit(context.Background(), func(ctx context.Context) { called = true })
+
+		assert.True(t, called)
+	})
+
+	t.Run("Should ignore rollback callback without transaction", func(t *testing.T) {
+		called := false
+
+		OnRollback(context.Background(), func(ctx context.Context) { called = true })
+
+		assert.False(t, called)
+	})
+
+	t.Run("Should run registered callbacks in order only once", func(t *testing.T) {
+		ctx, hooks := NewHooks(context.Background())
+		calls := []string{}
+		OnCommit(ctx, func(ctx context.Context) { calls = append(calls, "commit 1") })
+		OnCommit(ctx, func(ctx context.Context) { calls = append(calls, "commit 2") })
+		OnRollback(ctx, func(ctx context.Context) { calls = append(calls, "rollback") })
+
+		assert.Empty(t, calls)
+		hooks.RunCommit(ctx)
+		hooks.RunCommit(ctx)
+
+		assert.Equal(t, []string{"commit 1", "commit 2"}, calls)
+	})
+
+	t.Run("Should continue running callbacks after a panic", func(t *testing.T) {
+		ctx, hooks := NewHooks(context.Background())
+		called := false
+		OnRollback(ctx, func(ctx context.Context) { panic("hook fail") })
+		OnRollback(ctx, func(ctx context.Context) { called = true })
+
+		assert.NotPanics(t, func() { hooks.RunRollback(ctx) })
+		assert.True(t, called)
+	})
+
+	t.Run("Should merge nested callbacks into the outer hooks", func(t *testing.T) {
+		ctx, outer := NewHooks(context.Background())
+		nestedCtx, nested := NewHooks(ctx)
+		commits, rollbacks := 0, 0
+		OnCommit(nestedCtx, func(ctx context.Context) { commits++ })
+		OnRollback(nestedCtx, func(ctx context.Context) { rollbacks++ })
+
+		outer.Merge(nested)
+		nested.RunCommit(ctx)
+		assert.Zero(t, commits)
+
+		outer.RunCommit(ctx)
+		outer.RunRollback(ctx)
+		assert.Equal(t, 1, commits)
+		assert.Equal(t, 1, rollbacks)
+	})
+}
@@ -14,11 +14,19 @@ func NewMockTransaction() Transaction {
 	return mockSqlTransaction{}
 }
 
-// Execute executes a mockSqlTransaction.
+// Execute executes a mockSqlTransaction. The OnCommit callbacks run immediately after fn succeeds,
+// and the OnRollback callbacks immediately after fn fails.
 //
 // ctx: The context for the transaction.
 // fn: The function to be executed.
 // Returns an error.
 func (m mockSqlTransaction) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
-	return fn(ctx)
+	txCtx, hooks := NewHooks(ctx)
+	if err := fn(txCtx); err != nil {
+		hooks.RunRollback(ctx)
+		return err
+	}
+
+	hooks.RunCommit(ctx)
+	return nil
 }
@@ -26,4 +26,30 @@ func TestMockTransaction(t *testing.T) {
 
 		assert.ErrorIs(t, m.Execute(context.Background(), f), expectedErr)
 	})
+
+	t.Run("execute commit hooks", func(t *testing.T) {
+		committed, rolledBack := false, false
+		f := func(ctx context.Context) error {
+			OnCommit(ctx, func(ctx context.Context) { committed = true })
+			OnRollback(ctx, func(ctx context.Context) { rolledBack = true })
+			return nil
+		}
+
+		assert.NoError(t, NewMockTransaction().Execute(context.Background(), f))
+		assert.True(t, committed)
+		assert.False(t, rolledBack)
+	})
+
+	t.Run("execute rollback hooks", func(t *testing.T) {
+		committed, rolledBack := false, false
+		f := func(ctx context.Context) error {
+			OnCommit(ctx, func(ctx context.Context) { committed = true })
+			OnRollback(ctx, func(ctx context.Context) { rolledBack = true })
+			return errors.New("could not execute")
+		}
+
+		assert.Error(t, NewMockTransaction().Execute(context.Background(), f))
+		assert.False(t, committed)
+		assert.True(t, rolledBack)
+	})
 }
@@ -0,0 +1,18 @@
+package types
+
+// CursorPage is the cursor (keyset) page response contract
+type CursorPage[T any] struct {
+	Content        []T    `json:"content"`
+	NextCursor     string `json:"nextCursor,omitempty"`
+	PreviousCursor string `json:"previousCursor,omitempty"`
+}
+
+// HasNext returns true if there is a next page
+func (p *CursorPage[T]) HasNext() bool {
+	return p.NextCursor != ""
+}
+
+// HasPrevious returns true if there is a previous page
+func (p *CursorPage[T]) HasPrevious() bool {
+	return p.PreviousCursor != ""
+}
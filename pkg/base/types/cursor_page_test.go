@@ -0,0 +1,23 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorPage(t *testing.T) {
+	t.Run("Should return false when page has no cursors", func(t *testing.T) {
+		page := CursorPage[int]{Content: []int{1}}
+
+		assert.False(t, page.HasNext())
+		assert.False(t, page.HasPrevious())
+	})
+
+	t.Run("Should return true when page has cursors", func(t *testing.T) {
+		page := CursorPage[int]{Content: []int{1}, NextCursor: "next", PreviousCursor: "previous"}
+
+		assert.True(t, page.HasNext())
+		assert.True(t, page.HasPrevious())
+	})
+}
@@ -1,14 +1,105 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
+const (
+	pageLinkPageParam string = "page"
+	pageLinkSizeParam string = "size"
+)
+
 // Page is the page response contract
 type Page[T any] struct {
-	Content       []T    `json:"content"`
-	TotalElements uint64 `json:"totalElements"`
+	Content       []T        `json:"content"`
+	TotalElements uint64     `json:"totalElements"`
+	Page          uint16     `json:"page"`
+	Size          uint16     `json:"size"`
+	TotalPages    uint64     `json:"totalPages"`
+	First         bool       `json:"first"`
+	Last          bool       `json:"last"`
+	Links         *PageLinks `json:"links,omitempty"`
+}
+
+// PageLinks is the HATEOAS-style links contract of a page
+type PageLinks struct {
+	Self     string `json:"self"`
+	First    string `json:"first"`
+	Previous string `json:"previous,omitempty"`
+	Next     string `json:"next,omitempty"`
+	Last     string `json:"last"`
+}
+
+// NewPage returns a new page pointer with the metadata calculated from the total elements and the page request
+func NewPage[T any](content []T, totalElements uint64, request *PageRequest) *Page[T] {
+	page := &Page[T]{Content: content, TotalElements: totalElements, Page: request.Page, Size: request.Size}
+	if request.Size > 0 {
+		page.TotalPages = (totalElements + uint64(request.Size) - 1) / uint64(request.Size)
+	}
+	page.First = request.Page <= 1
+	page.Last = uint64(request.Page) >= page.TotalPages
+	return page
+}
+
+// MapPage returns a new page pointer with the content converted by fn and the same metadata, ex: from Page[Entity] to Page[DTO]
+func MapPage[T any, R any](page *Page[T], fn func(T) R) *Page[R] {
+	content := make([]R, 0, len(page.Content))
+	for _, item := range page.Content {
+		content = append(content, fn(item))
+	}
+
+	return &Page[R]{
+		Content:       content,
+		TotalElements: page.TotalElements,
+		Page:          page.Page,
+		Size:          page.Size,
+		TotalPages:    page.TotalPages,
+		First:         page.First,
+		Last:          page.Last,
+		Links:         page.Links,
+	}
+}
+
+// WithLinks fills the page links from the request url, replacing its page and size query params
+func (p *Page[T]) WithLinks(requestURL string) (*Page[T], error) {
+	base, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	lastPage := uint64(1)
+	if p.TotalPages > 1 {
+		lastPage = p.TotalPages
+	}
+
+	p.Links = &PageLinks{
+		Self:  p.link(*base, uint64(p.Page)),
+		First: p.link(*base, 1),
+		Last:  p.link(*base, lastPage),
+	}
+
+	if !p.First {
+		p.Links.Previous = p.link(*base, uint64(p.Page)-1)
+	}
+
+	if !p.Last {
+		p.Links.Next = p.link(*base, uint64(p.Page)+1)
+	}
+
+	return p, nil
+}
+
+// link returns the url of the page number
+func (p *Page[T]) link(base url.URL, page uint64) string {
+	query := base.Query()
+	query.Set(pageLinkPageParam, strconv.FormatUint(page, 10))
+	query.Set(pageLinkSizeParam, strconv.FormatUint(uint64(p.Size), 10))
+	base.RawQuery = query.Encode()
+	return base.String()
 }
 
 // PageRequest is the contract of request page
@@ -28,8 +119,18 @@ func (p *PageRequest) GetOrder() string {
 	orders := make([]string, 0, len(p.Order))
 
 	for _, order := range p.Order {
-		orders = append(orders, fmt.Sprintf("%s %s", order.Field, order.Direction))
+		orders = append(orders, fmt.Sprintf("%s %s", order.Expression(), order.Direction))
 	}
 
 	return strings.Join(orders, ", ")
 }
+
+// Validate checks if all sorts of the order are valid, so they are safe to be used in the ORDER BY clause.
+func (p *PageRequest) Validate() error {
+	errs := make([]error, 0, len(p.Order))
+	for _, order := range p.Order {
+		errs = append(errs, order.Validate())
+	}
+
+	return errors.Join(errs...)
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,3 +53,88 @@ func TestNewPageRequest(t *testing.T) {
 		assert.Equal(t, "field1 ASC, field2 DESC", result.GetOrder())
 	})
 }
+
+func TestPageRequestValidate(t *testing.T) {
+	t.Run("Should return no error when all sorts are valid", func(t *testing.T) {
+		name, _ := SortableFields{"name": "LOWER(u.name)"}.NewSort(ASC, "name")
+		page := NewPageRequest(1, 10, []Sort{name, NewSort(DESC, "u.id")})
+
+		assert.NoError(t, page.Validate())
+		assert.Equal(t, "LOWER(u.name) ASC, u.id DESC", page.GetOrder())
+	})
+
+	t.Run("Should return error when a sort is invalid", func(t *testing.T) {
+		page := NewPageRequest(1, 10, []Sort{NewSort(ASC, "name"), NewSort(ASC, "name; DROP TABLE users")})
+
+		assert.EqualError(t, page.Validate(), "invalid sort field name; DROP TABLE users")
+	})
+}
+
+func TestNewPage(t *testing.T) {
+	t.Run("Should return page metadata of the first page", func(t *testing.T) {
+		result := NewPage([]int{1, 2}, 5, NewPageRequest(1, 2, nil))
+
+		assert.Equal(t, &Page[int]{Content: []int{1, 2}, TotalElements: 5, Page: 1, Size: 2, TotalPages: 3, First: true, Last: false}, result)
+	})
+
+	t.Run("Should return page metadata of the last page", func(t *testing.T) {
+		result := NewPage([]int{5}, 5, NewPageRequest(3, 2, nil))
+
+		assert.Equal(t, uint64(3), result.TotalPages)
+		assert.False(t, result.First)
+		assert.True(t, result.Last)
+	})
+
+	t.Run("Should return first and last page when there are no elements", func(t *testing.T) {
+		result := NewPage([]int{}, 0, NewPageRequest(1, 10, nil))
+
+		assert.Equal(t, uint64(0), result.TotalPages)
+		assert.True(t, result.First)
+		assert.True(t, result.Last)
+	})
+}
+
+func TestMapPage(t *testing.T) {
+	t.Run("Should map page content keeping the metadata", func(t *testing.T) {
+		page := NewPage([]int{1, 2}, 5, NewPageRequest(2, 2, nil))
+
+		result := MapPage(page, func(item int) string { return fmt.Sprintf("item %d", item) })
+
+		assert.Equal(t, []string{"item 1", "item 2"}, result.Content)
+		assert.Equal(t, page.TotalElements, result.TotalElements)
+		assert.Equal(t, page.Page, result.Page)
+		assert.Equal(t, page.Size, result.Size)
+		assert.Equal(t, page.TotalPages, result.TotalPages)
+		assert.Equal(t, page.First, result.First)
+		assert.Equal(t, page.Last, result.Last)
+	})
+}
+
+func TestPageWithLinks(t *testing.T) {
+	t.Run("Should return page links replacing the page and size params", func(t *testing.T) {
+		result, err := NewPage([]int{3, 4}, 5, NewPageRequest(2, 2, nil)).WithLinks("http://localhost/users?name=user&page=9")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &PageLinks{
+			Self:     "http://localhost/users?name=user&page=2&size=2",
+			First:    "http://localhost/users?name=user&page=1&size=2",
+			Previous: "http://localhost/users?name=user&page=1&size=2",
+			Next:     "http://localhost/users?name=user&page=3&size=2",
+			Last:     "http://localhost/users?name=user&page=3&size=2",
+		}, result.Links)
+	})
+
+	t.Run("Should return page links without previous and next of a single page", func(t *testing.T) {
+		result, err := NewPage([]int{}, 0, NewPageRequest(1, 2, nil)).WithLinks("/users")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &PageLinks{Self: "/users?page=1&size=2", First: "/users?page=1&size=2", Last: "/users?page=1&size=2"}, result.Links)
+	})
+
+	t.Run("Should return error when url is invalid", func(t *testing.T) {
+		result, err := NewPage([]int{}, 0, NewPageRequest(1, 2, nil)).WithLinks("://invalid")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -1,5 +1,19 @@
 package types
 
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	error_sort_direction_invalid string = "invalid sort direction %s for field %s. Set ASC or DESC"
+	error_sort_field_invalid     string = "invalid sort field %s"
+	error_sort_field_not_allowed string = "sort field %s is not allowed"
+)
+
+// sortFieldPattern matches the sort fields that are plain column identifiers, optionally with the table alias (ex: u.name).
+var sortFieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
 // SortDirection is the field sort direction
 type SortDirection string
 
@@ -23,11 +37,52 @@ func (sd SortDirection) IsValid() bool {
 
 // Sort is the contract to sort an field
 type Sort struct {
-	Direction SortDirection
-	Field     string
+	Direction  SortDirection
+	Field      string
+	expression string
 }
 
 // NewSort returns a new Sort
 func NewSort(direction SortDirection, field string) Sort {
-	return Sort{direction, field}
+	return Sort{Direction: direction, Field: field}
+}
+
+// Expression returns the SQL expression of the sort: the expression of the SortableFields allow-list
+// when the sort was resolved by it, or the field itself.
+func (s Sort) Expression() string {
+	if s.expression != "" {
+		return s.expression
+	}
+
+	return s.Field
+}
+
+// Validate checks if the sort direction is valid, and if the field was resolved by a SortableFields allow-list
+// or is a plain column identifier (ex: name or u.name), so it's safe to be used in the ORDER BY clause.
+func (s Sort) Validate() error {
+	if !s.Direction.IsValid() {
+		return fmt.Errorf(error_sort_direction_invalid, s.Direction, s.Field)
+	}
+
+	if s.expression == "" && !sortFieldPattern.MatchString(s.Field) {
+		return fmt.Errorf(error_sort_field_invalid, s.Field)
+	}
+
+	return nil
+}
+
+// SortableFields is the allow-list of the sortable fields, mapping the API field names to the SQL expressions.
+// The expressions are trusted, so they must never come from the request.
+type SortableFields map[string]string
+
+// NewSort returns a new Sort with the SQL expression of the API field name,
+// or an error if the field is not allowed or the direction is invalid.
+func (f SortableFields) NewSort(direction SortDirection, field string) (Sort, error) {
+	expression, allowed := f[field]
+	if !allowed {
+		return Sort{}, fmt.Errorf(error_sort_field_not_allowed, field)
+	}
+
+	sort := Sort{Direction: direction, Field: field, expression: expression}
+	return sort, sort.Validate()
 }
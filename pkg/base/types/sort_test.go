@@ -34,3 +34,45 @@ func TestSortDirection_IsValid(t *testing.T) {
 		assert.False(t, result)
 	})
 }
+
+func TestSortValidate(t *testing.T) {
+	t.Run("Should return no error for plain column identifiers", func(t *testing.T) {
+		assert.NoError(t, NewSort(ASC, "name").Validate())
+		assert.NoError(t, NewSort(DESC, "u.birth_date").Validate())
+	})
+
+	t.Run("Should return error for invalid direction", func(t *testing.T) {
+		assert.EqualError(t, NewSort("asc; DROP TABLE users", "name").Validate(), "invalid sort direction asc; DROP TABLE users for field name. Set ASC or DESC")
+	})
+
+	t.Run("Should return error for fields that are not plain column identifiers", func(t *testing.T) {
+		for _, field := range []string{"name; DROP TABLE users", "LOWER(name)", "1", "u.name.x", ""} {
+			assert.EqualError(t, NewSort(ASC, field).Validate(), "invalid sort field "+field)
+		}
+	})
+}
+
+func TestSortableFields(t *testing.T) {
+	fields := SortableFields{"name": "LOWER(u.name)", "birthday": "u.birthday"}
+
+	t.Run("Should return sort with the allow-list expression", func(t *testing.T) {
+		result, err := fields.NewSort(DESC, "name")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "name", result.Field)
+		assert.Equal(t, "LOWER(u.name)", result.Expression())
+		assert.NoError(t, result.Validate())
+	})
+
+	t.Run("Should return error when field is not allowed", func(t *testing.T) {
+		_, err := fields.NewSort(ASC, "password")
+
+		assert.EqualError(t, err, "sort field password is not allowed")
+	})
+
+	t.Run("Should return error when direction is invalid", func(t *testing.T) {
+		_, err := fields.NewSort("INVALID", "name")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,140 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hash/fnv"
+)
+
+const (
+	advisoryLockQuery        string = "SELECT pg_advisory_lock($1)"
+	advisoryTryLockQuery     string = "SELECT pg_try_advisory_lock($1)"
+	advisoryUnlockQuery      string = "SELECT pg_advisory_unlock($1)"
+	advisoryXactLockQuery    string = "SELECT pg_advisory_xact_lock($1)"
+	advisoryXactTryLockQuery string = "SELECT pg_try_advisory_xact_lock($1)"
+
+	lock_key_is_empty_error string = "lock key is empty"
+)
+
+// ErrLockNotAcquired is returned by TryLock when the lock is held by another session or transaction.
+var ErrLockNotAcquired = errors.New("lock not acquired")
+
+// AdvisoryLock is a PostgreSQL advisory lock. A session lock holds a dedicated connection until it's unlocked,
+// and a transaction lock is released when the transaction ends.
+type AdvisoryLock struct {
+	key  int64
+	conn *sql.Conn
+}
+
+// Lock acquires the advisory lock of the key, waiting until it's released by the other holders or the context is done.
+// Inside a transaction the lock is transaction scoped, otherwise it's session scoped and must be unlocked.
+//
+// ctx: the context of the lock.
+// key: the lock key, ex: the name of the background job.
+// Returns a pointer to AdvisoryLock and an error.
+func Lock(ctx context.Context, key string) (*AdvisoryLock, error) {
+	return acquireAdvisoryLock(ctx, key, advisoryLockQuery, advisoryXactLockQuery)
+}
+
+// TryLock acquires the advisory lock of the key without waiting.
+// Inside a transaction the lock is transaction scoped, otherwise it's session scoped and must be unlocked.
+//
+// ctx: the context of the lock.
+// key: the lock key, ex: the name of the background job.
+// Returns a pointer to AdvisoryLock and an error, ErrLockNotAcquired when the lock is held by another session or transaction.
+func TryLock(ctx context.Context, key string) (*AdvisoryLock, error) {
+	return acquireAdvisoryLock(ctx, key, advisoryTryLockQuery, advisoryXactTryLockQuery)
+}
+
+// Unlock releases a session lock and its connection. A transaction lock is released by the transaction end.
+//
+// No parameters.
+// Returns an error.
+func (l *AdvisoryLock) Unlock() error {
+	if l.conn == nil {
+		return nil
+	}
+
+	defer closer(l.conn)
+	_, err := l.conn.ExecContext(context.Background(), advisoryUnlockQuery, l.key)
+	return err
+}
+
+// ping checks if the connection of a session lock is alive, since the lock is released when the session ends.
+//
+// ctx: the context of the check.
+// Returns an error.
+func (l *AdvisoryLock) ping(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+
+	return l.conn.PingContext(ctx)
+}
+
+// acquireAdvisoryLock executes the lock query in the transaction of the context, or in a dedicated connection.
+//
+// ctx: the context of the lock.
+// key: the lock key.
+// sessionQuery: the session scoped lock query.
+// xactQuery: the transaction scoped lock query.
+// Returns a pointer to AdvisoryLock and an error.
+func acquireAdvisoryLock(ctx context.Context, key string, sessionQuery, xactQuery string) (*AdvisoryLock, error) {
+	if key == "" {
+		return nil, errors.New(lock_key_is_empty_error)
+	}
+
+	instance := getInstance(ctx)
+	if instance == nil {
+		return nil, errors.New(db_not_initialized_error)
+	}
+
+	lock := &AdvisoryLock{key: advisoryLockKey(key)}
+	if tx, ok := transactionOf(ctx, instance); ok {
+		if err := checkAcquired(tx.QueryRowContext(ctx, xactQuery, lock.key)); err != nil {
+			return nil, err
+		}
+		return lock, nil
+	}
+
+	conn, err := instance.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkAcquired(conn.QueryRowContext(ctx, sessionQuery, lock.key)); err != nil {
+		closer(conn)
+		return nil, err
+	}
+
+	lock.conn = conn
+	return lock, nil
+}
+
+// checkAcquired scans the result of the lock query, that is a bool for the try lock queries and void for the lock queries.
+//
+// row: the row of the lock query.
+// Returns an error, ErrLockNotAcquired when the try lock returns false.
+func checkAcquired(row *sql.Row) error {
+	var acquired any
+	if err := row.Scan(&acquired); err != nil {
+		return err
+	}
+
+	if locked, ok := acquired.(bool); ok && !locked {
+		return ErrLockNotAcquired
+	}
+
+	return nil
+}
+
+// advisoryLockKey returns the bigint key of the lock, the FNV-1a hash of the key.
+//
+// key: the lock key.
+// Returns the int64 key.
+func advisoryLockKey(key string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	return int64(hash.Sum64())
+}
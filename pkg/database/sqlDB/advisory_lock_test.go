@@ -0,0 +1,172 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryLockWithoutInitialize(t *testing.T) {
+	ctx := context.Background()
+	sqlDBInstance = nil
+
+	t.Run("Should return error when lock key is empty", func(t *testing.T) {
+		lock, err := TryLock(ctx, "")
+		assert.EqualError(t, err, lock_key_is_empty_error)
+		assert.Nil(t, lock)
+
+		elector, err := NewLeaderElector(ctx, "", LeaderCallbacks{})
+		assert.EqualError(t, err, lock_key_is_empty_error)
+		assert.Nil(t, elector)
+	})
+
+	t.Run("Should return error when db is not initialized", func(t *testing.T) {
+		lock, err := Lock(ctx, "job")
+		assert.EqualError(t, err, db_not_initialized_error)
+		assert.Nil(t, lock)
+
+		elector, err := NewLeaderElector(ctx, "job", LeaderCallbacks{})
+		assert.EqualError(t, err, db_not_initialized_error)
+		assert.Nil(t, elector)
+	})
+
+	t.Run("Should remove the transaction of the context", func(t *testing.T) {
+		instance := &sql.DB{}
+		txCtx := withTransaction(ctx, instance, &sql.Tx{})
+
+		_, inTx := transactionOf(withoutTransaction(txCtx), instance)
+		assert.False(t, inTx)
+	})
+
+	t.Run("Should return the same key for the same lock key", func(t *testing.T) {
+		assert.Equal(t, advisoryLockKey("job"), advisoryLockKey("job"))
+		assert.NotEqual(t, advisoryLockKey("job"), advisoryLockKey("other-job"))
+	})
+}
+
+func TestAdvisoryLock(t *testing.T) {
+	ctx := context.Background()
+	InitializeSqlDBTest()
+
+	t.Run("Should not acquire a session lock held by another session", func(t *testing.T) {
+		lock, err := TryLock(ctx, "session-job")
+		assert.NoError(t, err)
+
+		other, err := TryLock(ctx, "session-job")
+		assert.ErrorIs(t, err, ErrLockNotAcquired)
+		assert.Nil(t, other)
+
+		assert.NoError(t, lock.Unlock())
+		other, err = Lock(ctx, "session-job")
+		assert.NoError(t, err)
+		assert.NoError(t, other.Unlock())
+	})
+
+	t.Run("Should wait for the lock until the context is done", func(t *testing.T) {
+		lock, err := Lock(ctx, "wait-job")
+		assert.NoError(t, err)
+		defer lock.Unlock()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+		other, err := Lock(timeoutCtx, "wait-job")
+
+		assert.Error(t, err)
+		assert.Nil(t, other)
+	})
+
+	t.Run("Should release the transaction lock when the transaction ends", func(t *testing.T) {
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if _, err := TryLock(ctx, "transaction-job"); err != nil {
+				return err
+			}
+
+			_, err := TryLock(context.Background(), "transaction-job")
+			assert.ErrorIs(t, err, ErrLockNotAcquired)
+			return errors.New("rollback")
+		})
+		assert.EqualError(t, err, "rollback")
+
+		lock, err := TryLock(ctx, "transaction-job")
+		assert.NoError(t, err)
+		assert.NoError(t, lock.Unlock())
+	})
+}
+
+func TestLeaderElector(t *testing.T) {
+	ctx := context.Background()
+	InitializeSqlDBTest()
+
+	t.Run("Should keep one leader and elect another when it's closed", func(t *testing.T) {
+		elected := make(chan string, 2)
+		revoked := make(chan string, 2)
+		callbacks := func(name string) LeaderCallbacks {
+			return LeaderCallbacks{
+				OnElected: func(context.Context) { elected <- name },
+				OnRevoked: func() { revoked <- name },
+			}
+		}
+
+		first, err := NewLeaderElector(ctx, "leader-job", callbacks("first"))
+		assert.NoError(t, err)
+		assert.Equal(t, "first", waitLeaderEvent(t, elected))
+
+		second, err := NewLeaderElector(ctx, "leader-job", callbacks("second"))
+		assert.NoError(t, err)
+		defer second.Close()
+
+		assert.True(t, first.IsLeader())
+		assert.Never(t, second.IsLeader, leaderElectionInterval+time.Second, 100*time.Millisecond)
+
+		first.Close()
+		assert.Equal(t, "first", waitLeaderEvent(t, revoked))
+		assert.False(t, first.IsLeader())
+		assert.Equal(t, "second", waitLeaderEvent(t, elected))
+		assert.True(t, second.IsLeader())
+	})
+}
+
+func TestLeaderElectorInTransaction(t *testing.T) {
+	ctx := context.Background()
+	InitializeSqlDBTest()
+
+	t.Run("Should hold the leadership with a session lock when started in a transaction", func(t *testing.T) {
+		elected := make(chan string, 1)
+		var elector *LeaderElector
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			var err error
+			elector, err = NewLeaderElector(ctx, "transaction-leader-job", LeaderCallbacks{
+				OnElected: func(context.Context) { elected <- "leader" },
+			})
+			if err != nil {
+				return err
+			}
+
+			assert.Equal(t, "leader", waitLeaderEvent(t, elected))
+			return nil
+		})
+		assert.NoError(t, err)
+
+		_, err = TryLock(ctx, "transaction-leader-job")
+		assert.ErrorIs(t, err, ErrLockNotAcquired)
+
+		elector.Close()
+		lock, err := TryLock(ctx, "transaction-leader-job")
+		assert.NoError(t, err)
+		assert.NoError(t, lock.Unlock())
+	})
+}
+
+func waitLeaderEvent(t *testing.T, ch chan string) string {
+	select {
+	case name := <-ch:
+		return name
+	case <-time.After(2 * leaderElectionInterval):
+		t.Fatal("Test didn't finish after 10s")
+		return ""
+	}
+}
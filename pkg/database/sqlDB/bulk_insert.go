@@ -0,0 +1,202 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	bulk_insert_invalid_model_error string = "bulk insert model %s must be a struct with at least one column"
+)
+
+// BulkInsert is a struct for inserting many models into a table with the PostgreSQL COPY protocol.
+//
+// The table and columns are derived from the model like in the Repository, and the auto columns are omitted.
+// In the dialects without bulk copy, the models are inserted with a prepared INSERT statement.
+type BulkInsert[T any] struct {
+	ctx     context.Context
+	table   string
+	columns []repositoryColumn
+	err     error
+}
+
+// NewBulkInsert creates a new pointer to BulkInsert struct.
+//
+// ctx: the context.Context for the bulk insert
+// Returns a pointer to BulkInsert struct
+func NewBulkInsert[T any](ctx context.Context) *BulkInsert[T] {
+	modelType := reflect.TypeOf((*T)(nil)).Elem()
+	b := &BulkInsert[T]{ctx: ctx, table: tableName(modelType)}
+	if modelType.Kind() == reflect.Struct {
+		for _, column := range appendRepositoryColumns(nil, modelType, nil) {
+			if !column.auto {
+				b.columns = append(b.columns, column)
+			}
+		}
+	}
+
+	if len(b.columns) == 0 {
+		b.err = fmt.Errorf(bulk_insert_invalid_model_error, modelType)
+	}
+
+	return b
+}
+
+// Execute inserts all models of the slice and returns the number of rows inserted.
+// It's executed in the transaction of the context, or in a new transaction.
+//
+// models: the models to insert
+// Returns the number of rows inserted and an error.
+func (b *BulkInsert[T]) Execute(models []T) (int64, error) {
+	return b.ExecuteInInstance(getInstance(b.ctx), models)
+}
+
+// ExecuteInInstance inserts all models of the slice in the provided database instance and returns the number of rows inserted.
+//
+// instance: the sql database instance to insert the models in.
+// models: the models to insert
+// Returns the number of rows inserted and an error.
+func (b *BulkInsert[T]) ExecuteInInstance(instance *sql.DB, models []T) (int64, error) {
+	i := 0
+	return b.copyInInstance(instance, func() (T, bool, error) {
+		if i == len(models) {
+			var zero T
+			return zero, false, nil
+		}
+		i++
+		return models[i-1], true, nil
+	})
+}
+
+// CopyFrom streams the models of the channel until it is closed and returns the number of rows inserted.
+// It's executed in the transaction of the context, or in a new transaction. The channel stops being read
+// when an error occurs or the context is canceled.
+//
+// models: the channel of models to insert
+// Returns the number of rows inserted and an error.
+func (b *BulkInsert[T]) CopyFrom(models <-chan T) (int64, error) {
+	return b.CopyFromInInstance(getInstance(b.ctx), models)
+}
+
+// CopyFromInInstance streams the models of the channel in the provided database instance and returns the number of rows inserted.
+//
+// instance: the sql database instance to insert the models in.
+// models: the channel of models to insert
+// Returns the number of rows inserted and an error.
+func (b *BulkInsert[T]) CopyFromInInstance(instance *sql.DB, models <-chan T) (int64, error) {
+	return b.copyInInstance(instance, func() (T, bool, error) {
+		select {
+		case <-b.ctx.Done():
+			var zero T
+			return zero, false, b.ctx.Err()
+		case model, ok := <-models:
+			return model, ok, nil
+		}
+	})
+}
+
+// copyInInstance validates and executes the copy in the transaction of the context, or in a new transaction of the instance.
+//
+// instance: the sql database instance to insert the models in.
+// next: the function that returns the next model to insert, false when there are no more models, or an error.
+// Returns the number of rows inserted and an error.
+func (b *BulkInsert[T]) copyInInstance(instance *sql.DB, next func() (T, bool, error)) (int64, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	if instance == nil {
+		return 0, errors.New(db_not_initialized_error)
+	}
+
+	dialect := dialectOf(instance)
+	if tx, ok := transactionOf(b.ctx, instance); ok {
+		return b.copy(tx, dialect, next)
+	}
+
+	tx, err := instance.BeginTx(b.ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := b.copy(tx, dialect, next)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return count, tx.Commit()
+}
+
+// copy streams the models into the table in the transaction.
+//
+// tx: the transaction to execute the copy in.
+// dialect: the dialect of the database.
+// next: the function that returns the next model to insert, false when there are no more models, or an error.
+// Returns the number of rows inserted and an error.
+func (b *BulkInsert[T]) copy(tx *sql.Tx, dialect Dialect, next func() (T, bool, error)) (count int64, err error) {
+	names, placeholders := make([]string, 0, len(b.columns)), make([]string, 0, len(b.columns))
+	for _, column := range b.columns {
+		names = append(names, column.name)
+		placeholders = append(placeholders, dialect.Placeholder(len(placeholders)+1))
+	}
+
+	query, isCopy := dialect.CopyIn(b.table, names)
+	if !isCopy {
+		query = fmt.Sprintf(repositoryInsertQuery, b.table, strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	}
+
+	ctx, finish := startQueryHooks(b.ctx, STATEMENT_OPERATION, query, nil)
+	defer func() { finish(count, err) }()
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer closer(stmt)
+
+	for {
+		model, ok, err := next()
+		if err != nil {
+			return 0, err
+		}
+
+		if !ok {
+			break
+		}
+
+		value := reflect.ValueOf(&model).Elem()
+		args := make([]any, 0, len(b.columns))
+		for _, column := range b.columns {
+			args = append(args, argumentValue(dialect, fieldByIndex(value, column.index), column.isArray))
+		}
+
+		result, err := stmt.ExecContext(ctx, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		if !isCopy {
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return 0, err
+			}
+			count += affected
+		}
+	}
+
+	if !isCopy {
+		return count, nil
+	}
+
+	result, err := stmt.ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
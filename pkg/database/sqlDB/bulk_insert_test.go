@@ -0,0 +1,120 @@
+package sqlDB
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bulkContact struct {
+	ID    int `db:"id,auto"`
+	Name  string
+	Email string
+}
+
+func (bulkContact) TableName() string {
+	return "contacts"
+}
+
+func TestBulkInsertWithoutInitialize(t *testing.T) {
+	ctx := context.Background()
+	sqlDBInstance = nil
+
+	t.Run("Should return error when execute bulk insert with db not initialized error", func(t *testing.T) {
+		count, err := NewBulkInsert[bulkContact](ctx).Execute([]bulkContact{{Name: "Contact"}})
+
+		assert.EqualError(t, err, db_not_initialized_error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("Should return error when model has no columns", func(t *testing.T) {
+		count, err := NewBulkInsert[int](ctx).Execute([]int{1})
+
+		assert.EqualError(t, err, fmt.Sprintf(bulk_insert_invalid_model_error, "int"))
+		assert.Zero(t, count)
+	})
+
+	t.Run("Should derive table and columns omitting auto columns", func(t *testing.T) {
+		bulkInsert := NewBulkInsert[bulkContact](ctx)
+
+		assert.Equal(t, "contacts", bulkInsert.table)
+		assert.Len(t, bulkInsert.columns, 2)
+		assert.Equal(t, "name", bulkInsert.columns[0].name)
+		assert.Equal(t, "email", bulkInsert.columns[1].name)
+	})
+}
+
+func TestBulkInsert(t *testing.T) {
+	InitializeSqlDBTest()
+	ctx := context.Background()
+
+	countContacts := func(prefix string) int {
+		count, err := NewQuery[int](ctx, "SELECT COUNT(*) FROM contacts WHERE email LIKE $1", prefix+"%").One()
+		assert.NoError(t, err)
+		return *count
+	}
+
+	t.Run("Should insert slice of models", func(t *testing.T) {
+		contacts := make([]bulkContact, 0, 1000)
+		for i := 0; i < 1000; i++ {
+			contacts = append(contacts, bulkContact{Name: fmt.Sprintf("Bulk %d", i), Email: fmt.Sprintf("bulk-slice-%d@email.com", i)})
+		}
+
+		count, err := NewBulkInsert[bulkContact](ctx).Execute(contacts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), count)
+		assert.Equal(t, 1000, countContacts("bulk-slice-"))
+	})
+
+	t.Run("Should insert models from channel", func(t *testing.T) {
+		contacts := make(chan bulkContact)
+		go func() {
+			defer close(contacts)
+			for i := 0; i < 100; i++ {
+				contacts <- bulkContact{Name: fmt.Sprintf("Bulk %d", i), Email: fmt.Sprintf("bulk-channel-%d@email.com", i)}
+			}
+		}()
+
+		count, err := NewBulkInsert[bulkContact](ctx).CopyFrom(contacts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), count)
+		assert.Equal(t, 100, countContacts("bulk-channel-"))
+	})
+
+	t.Run("Should rollback bulk insert inside a transaction", func(t *testing.T) {
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if _, err := NewBulkInsert[bulkContact](ctx).Execute([]bulkContact{{Name: "Bulk", Email: "bulk-tx@email.com"}}); err != nil {
+				return err
+			}
+			return errors.New("rollback")
+		})
+
+		assert.EqualError(t, err, "rollback")
+		assert.Equal(t, 0, countContacts("bulk-tx"))
+	})
+
+	t.Run("Should return error and insert nothing when a row is invalid", func(t *testing.T) {
+		contacts := []bulkContact{{Name: "Bulk", Email: "bulk-duplicated@email.com"}, {Name: "Bulk", Email: "bulk-duplicated@email.com"}}
+
+		count, err := NewBulkInsert[bulkContact](ctx).Execute(contacts)
+
+		assert.Error(t, err)
+		assert.Zero(t, count)
+		assert.Equal(t, 0, countContacts("bulk-duplicated"))
+	})
+
+	t.Run("Should stop reading the channel when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		count, err := NewBulkInsert[bulkContact](ctx).CopyFrom(make(chan bulkContact))
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, count)
+	})
+}
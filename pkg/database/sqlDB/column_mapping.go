@@ -0,0 +1,288 @@
+package sqlDB
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/lib/pq"
+)
+
+const (
+	db_tag string = "db"
+
+	column_not_mapped_error string = "column %s has no matching field in %s"
+)
+
+// scannerType is the reflect type of the sql.Scanner interface.
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// fieldMapping is the mapping metadata of a struct field that receives a column value.
+type fieldMapping struct {
+	index    []int
+	name     string
+	fullName string
+	isArray  bool
+}
+
+// columnMappingKey identifies a columns mapping by model type and result columns.
+type columnMappingKey struct {
+	modelType reflect.Type
+	columns   string
+}
+
+// rowMapper maps the result columns into the fields of a model.
+type rowMapper struct {
+	single  bool
+	isArray bool
+	fields  []fieldMapping
+	targets []int
+}
+
+var (
+	// typeFields caches the fields mapping metadata by model type.
+	typeFields sync.Map
+	// columnMappings caches the row mappers by model type and result columns.
+	columnMappings sync.Map
+)
+
+// newRowMapper creates a row mapper for the model type and the result columns.
+//
+// modelType: the type of the model that receives the rows
+// columns: the result columns
+// Returns a pointer to rowMapper and an error when strict column mapping is enabled and a column has no matching field.
+func newRowMapper(modelType reflect.Type, columns []string) (*rowMapper, error) {
+	key := columnMappingKey{modelType, strings.Join(columns, ",")}
+	cached, exists := columnMappings.Load(key)
+	if !exists {
+		cached, _ = columnMappings.LoadOrStore(key, buildRowMapper(modelType, columns))
+	}
+
+	mapper := cached.(*rowMapper)
+	if config.SQL_DB_STRICT_COLUMN_MAPPING {
+		for column, target := range mapper.targets {
+			if target < 0 {
+				return nil, fmt.Errorf(column_not_mapped_error, columns[column], modelType)
+			}
+		}
+	}
+
+	return mapper, nil
+}
+
+// buildRowMapper maps each column to the first unused field with the same prefixed name (ex: profile_id),
+// or else with the same name. Repeated column names are mapped in the fields declaration order,
+// so `SELECT u.id, p.id` fills User.Id and User.Profile.Id.
+//
+// modelType: the type of the model that receives the rows
+// columns: the result columns
+// Returns a pointer to rowMapper.
+func buildRowMapper(modelType reflect.Type, columns []string) *rowMapper {
+	if isColumnType(modelType) {
+		return &rowMapper{single: true, isArray: isArrayType(modelType)}
+	}
+
+	mapper := &rowMapper{fields: modelFields(modelType), targets: make([]int, len(columns))}
+	used := make([]bool, len(mapper.fields))
+	for column, name := range columns {
+		mapper.targets[column] = matchField(mapper.fields, used, name)
+	}
+
+	return mapper
+}
+
+// matchField returns the index of the first unused field matching the column, or -1 if there is none.
+func matchField(fields []fieldMapping, used []bool, column string) int {
+	for _, byFullName := range []bool{true, false} {
+		for i, field := range fields {
+			name := field.name
+			if byFullName {
+				name = field.fullName
+			}
+
+			if !used[i] && strings.EqualFold(name, column) {
+				used[i] = true
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// destinations returns the scan destinations of each column into the model.
+//
+// model: the addressable model value
+// Returns a slice of scan destinations.
+func (m *rowMapper) destinations(model reflect.Value) []any {
+	if m.single {
+		return []any{scanDestination(model, m.isArray)}
+	}
+
+	dest := make([]any, len(m.targets))
+	for column, target := range m.targets {
+		if target < 0 {
+			dest[column] = new(any)
+			continue
+		}
+
+		field := m.fields[target]
+		dest[column] = scanDestination(fieldByIndex(model, field.index), field.isArray)
+	}
+
+	return dest
+}
+
+// modelFields returns the cached fields mapping metadata of the struct type, including the nested and embedded struct fields.
+//
+// modelType: the struct type
+// Returns a slice of fieldMapping.
+func modelFields(modelType reflect.Type) []fieldMapping {
+	if cached, exists := typeFields.Load(modelType); exists {
+		return cached.([]fieldMapping)
+	}
+
+	fields, _ := typeFields.LoadOrStore(modelType, appendStructFields(nil, modelType, nil, ""))
+	return fields.([]fieldMapping)
+}
+
+// appendStructFields appends the fields mapping metadata of the struct type.
+//
+// fields: the fields mapping metadata to append to
+// structType: the struct type
+// index: the index path of the struct from the model
+// prefix: the column name prefix of the nested struct fields
+// Returns the slice of fieldMapping.
+func appendStructFields(fields []fieldMapping, structType reflect.Type, index []int, prefix string) []fieldMapping {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldIndex := append(append(make([]int, 0, len(index)+1), index...), i)
+
+		if field.Anonymous && !isColumnType(field.Type) {
+			// unexported embedded pointers can't be allocated
+			if field.Type.Kind() != reflect.Pointer || field.IsExported() {
+				fields = appendStructFields(fields, indirectType(field.Type), fieldIndex, prefix)
+			}
+			continue
+		}
+
+		name, ok := columnName(field)
+		if !field.IsExported() || !ok {
+			continue
+		}
+
+		if isColumnType(field.Type) {
+			fields = append(fields, fieldMapping{fieldIndex, name, prefix + name, isArrayType(field.Type)})
+		} else {
+			fields = appendStructFields(fields, indirectType(field.Type), fieldIndex, prefix+name+"_")
+		}
+	}
+
+	return fields
+}
+
+// fieldByIndex returns the nested field of the value, allocating the nil struct pointers in the path.
+func fieldByIndex(value reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(i)
+	}
+
+	return value
+}
+
+// scanDestination returns the pointer to the value to be used as scan destination, wrapping arrays with arrayDestination.
+func scanDestination(value reflect.Value, isArray bool) any {
+	if isArray {
+		return arrayDestination{value.Addr().Interface()}
+	}
+
+	return value.Addr().Interface()
+}
+
+// arrayDestination scans the PostgreSQL arrays with pq.Array, and the JSON arrays of the other dialects.
+type arrayDestination struct {
+	dest any
+}
+
+// Scan implements the sql.Scanner interface.
+func (a arrayDestination) Scan(src any) error {
+	var data []byte
+	switch value := src.(type) {
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	}
+
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, a.dest)
+	}
+
+	return pq.Array(a.dest).Scan(src)
+}
+
+// isColumnType returns true if the type receives a single column value instead of being mapped field by field.
+func isColumnType(t reflect.Type) bool {
+	isStruct, isTime, isNull, _ := reflectTypeValidations(indirectType(t))
+	return !isStruct || isTime || isNull || reflect.PointerTo(indirectType(t)).Implements(scannerType)
+}
+
+// isArrayType returns true if the type is a slice scanned as a database array.
+func isArrayType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// indirectType returns the element type of pointer types, or the type itself.
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
+
+// columnName returns the column name of a struct field from the db tag, or the snake_case field name when there is no tag.
+//
+// field: the struct field
+// (string, bool): returns the column name and false when the field is ignored with db:"-".
+func columnName(field reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get(db_tag), ",")
+	if name == "-" {
+		return "", false
+	}
+
+	if name == "" {
+		name = toSnakeCase(field.Name)
+	}
+	return name, true
+}
+
+// toSnakeCase converts a Go identifier into snake_case, keeping acronyms together (ex: UserID -> user_id).
+//
+// name: the identifier to convert
+// string: returns the snake_case name
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var result strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				result.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
@@ -0,0 +1,147 @@
+package sqlDB
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type mappingAudit struct {
+	CreatedAt time.Time
+}
+
+type mappingUser struct {
+	mappingAudit
+	ID       int
+	Name     string
+	Nickname *string
+	Email    sql.NullString `db:"mail"`
+	Tags     []string
+	Profile  *Profile
+	Ignored  string `db:"-"`
+	internal string
+}
+
+func TestColumnMapping(t *testing.T) {
+	userType := reflect.TypeOf(mappingUser{})
+
+	scan := func(mapper *rowMapper, model any, values ...any) {
+		for i, dest := range mapper.destinations(reflect.ValueOf(model).Elem()) {
+			if scanner, ok := dest.(sql.Scanner); ok {
+				assert.NoError(t, scanner.Scan(values[i]))
+				continue
+			}
+			reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(values[i]))
+		}
+	}
+
+	t.Run("Should map columns by name regardless of the columns order", func(t *testing.T) {
+		mapper, err := newRowMapper(userType, []string{"name", "id", "created_at"})
+		assert.NoError(t, err)
+
+		now := time.Now()
+		user := mappingUser{}
+		scan(mapper, &user, "User 1", 1, now)
+
+		assert.Equal(t, mappingUser{mappingAudit: mappingAudit{now}, ID: 1, Name: "User 1"}, user)
+	})
+
+	t.Run("Should map repeated columns to nested struct fields in declaration order", func(t *testing.T) {
+		mapper, err := newRowMapper(userType, []string{"id", "name", "id", "name"})
+		assert.NoError(t, err)
+
+		user := mappingUser{}
+		scan(mapper, &user, 1, "User 1", 2, "Profile 2")
+
+		assert.Equal(t, 1, user.ID)
+		assert.Equal(t, "User 1", user.Name)
+		assert.Equal(t, &Profile{Id: 2, Name: "Profile 2"}, user.Profile)
+	})
+
+	t.Run("Should map prefixed columns to nested struct fields", func(t *testing.T) {
+		mapper, err := newRowMapper(userType, []string{"profile_name", "PROFILE_ID", "id"})
+		assert.NoError(t, err)
+
+		user := mappingUser{}
+		scan(mapper, &user, "Profile 2", 2, 1)
+
+		assert.Equal(t, 1, user.ID)
+		assert.Equal(t, &Profile{Id: 2, Name: "Profile 2"}, user.Profile)
+	})
+
+	t.Run("Should map tagged, pointer, null and array fields", func(t *testing.T) {
+		mapper, err := newRowMapper(userType, []string{"mail", "nickname", "tags"})
+		assert.NoError(t, err)
+
+		nickname := "user"
+		user := mappingUser{}
+		destinations := mapper.destinations(reflect.ValueOf(&user).Elem())
+		assert.IsType(t, &sql.NullString{}, destinations[0])
+		assert.IsType(t, &user.Nickname, destinations[1])
+		assert.Equal(t, arrayDestination{&user.Tags}, destinations[2])
+
+		scan(mapper, &user, "user@email.com", &nickname, []byte("{a,b}"))
+		assert.Equal(t, "user@email.com", user.Email.String)
+		assert.Equal(t, &nickname, user.Nickname)
+		assert.Equal(t, []string{"a", "b"}, user.Tags)
+		assert.Nil(t, user.Profile)
+	})
+
+	t.Run("Should map JSON array fields", func(t *testing.T) {
+		mapper, err := newRowMapper(userType, []string{"tags"})
+		assert.NoError(t, err)
+
+		user := mappingUser{}
+		scan(mapper, &user, `["a","b"]`)
+
+		assert.Equal(t, []string{"a", "b"}, user.Tags)
+	})
+
+	t.Run("Should ignore unknown and ignored columns when strict mapping is disabled", func(t *testing.T) {
+		mapper, err := newRowMapper(userType, []string{"id", "unknown", "ignored", "internal"})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, -1, -1, -1}, mapper.targets)
+	})
+
+	t.Run("Should return error for unknown columns when strict mapping is enabled", func(t *testing.T) {
+		config.SQL_DB_STRICT_COLUMN_MAPPING = true
+		defer func() { config.SQL_DB_STRICT_COLUMN_MAPPING = false }()
+
+		mapper, err := newRowMapper(userType, []string{"id", "unknown"})
+		assert.EqualError(t, err, fmt.Sprintf(column_not_mapped_error, "unknown", userType))
+		assert.Nil(t, mapper)
+	})
+
+	t.Run("Should map the first column into non struct types", func(t *testing.T) {
+		mapper, err := newRowMapper(reflect.TypeOf(time.Time{}), []string{"now"})
+		assert.NoError(t, err)
+		assert.True(t, mapper.single)
+
+		var count uint64
+		assert.Equal(t, []any{&count}, mapper.destinations(reflect.ValueOf(&count).Elem()))
+	})
+
+	t.Run("Should cache the fields mapping metadata by type", func(t *testing.T) {
+		fields := modelFields(userType)
+		cached, exists := typeFields.Load(userType)
+
+		assert.True(t, exists)
+		assert.Equal(t, fields, cached)
+		assert.Len(t, fields, 8)
+	})
+}
+
+func TestToSnakeCase(t *testing.T) {
+	t.Run("Should convert field names to snake case", func(t *testing.T) {
+		assert.Equal(t, "id", toSnakeCase("Id"))
+		assert.Equal(t, "birth_date", toSnakeCase("BirthDate"))
+		assert.Equal(t, "user_id", toSnakeCase("UserID"))
+		assert.Equal(t, "http_server", toSnakeCase("HTTPServer"))
+		assert.Equal(t, "address2", toSnakeCase("Address2"))
+	})
+}
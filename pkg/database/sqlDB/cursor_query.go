@@ -0,0 +1,378 @@
+package sqlDB
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	cursorDataQuery string = "SELECT * FROM (%s) tb%s ORDER BY %s LIMIT %d"
+	cursorNext      string = "next"
+	cursorPrevious  string = "prev"
+
+	cursor_order_is_empty_error   string = "cursor query order is empty"
+	cursor_order_column_error     string = "cursor query order field %s is not a column of the query"
+	cursor_order_expression_error string = "cursor query order field %s must be a column of the query, got the expression %s"
+	cursor_order_null_error       string = "cursor query order field %s is null, the order columns must be NOT NULL"
+	cursor_invalid_error          string = "invalid cursor: %w"
+	cursor_values_mismatch_error  string = "invalid cursor: expected %d values, got %d"
+)
+
+// cursorColumnPattern matches the order expressions that are columns, with an optional table alias, ex: u.name.
+var cursorColumnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// cursor is the content of the opaque cursor of a cursor page.
+type cursor struct {
+	Values    []any  `json:"v"`
+	Direction string `json:"d"`
+}
+
+// CursorQuery is a struct for sql cursor (keyset) page query
+//
+// The rows are filtered by the order fields values of the cursor instead of using OFFSET, and the total is not counted.
+// The order fields must be columns of the query (aliased when needed) and should end with a unique column, like the id.
+// The order columns must be NOT NULL, because a NULL value can't be compared with the cursor and returns an error.
+// The expressions of types.SortableFields, like LOWER(u.name), are not allowed, the expression must be selected
+// as a column of the query and sorted by its alias.
+type CursorQuery[T any] struct {
+	ctx    context.Context
+	page   *types.PageRequest
+	cursor string
+	query  string
+	args   []any
+}
+
+// NewCursorQuery creates a new pointer to CursorQuery struct.
+//
+// ctx: the context.Context for the query
+// page: the types.PageRequest with the size and the order of the query, the page number is ignored
+// cursor: the opaque cursor returned in a previous page, or empty for the first page
+// query: the query string to execute
+// params: variadic interface{} for additional parameters
+// Returns a pointer to CursorQuery struct
+func NewCursorQuery[T any](ctx context.Context, page *types.PageRequest, cursor string, query string, params ...any) *CursorQuery[T] {
+	return &CursorQuery[T]{ctx, page, cursor, query, params}
+}
+
+// Execute returns a pointer of cursor page type with slice of T data. The query is executed in a read replica when there is one.
+//
+// No parameters.
+// Returns a pointer to types.CursorPage and an error.
+func (q *CursorQuery[T]) Execute() (*types.CursorPage[T], error) {
+	return q.ExecuteInInstance(getReadInstance(q.ctx))
+}
+
+// ExecuteInInstance executes the cursor query in the given database instance.
+//
+// instance: the database instance to execute the query in.
+// Returns a pointer to types.CursorPage and an error.
+func (q *CursorQuery[T]) ExecuteInInstance(instance *sql.DB) (*types.CursorPage[T], error) {
+	if err := q.validate(instance); err != nil {
+		return nil, err
+	}
+
+	current, err := decodeCursor(q.cursor, len(q.page.Order))
+	if err != nil {
+		return nil, err
+	}
+
+	content, values, err := q.fetch(instance, current)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(content) > int(q.page.Size)
+	if hasMore {
+		content, values = content[:q.page.Size], values[:q.page.Size]
+	}
+
+	if current.Direction == cursorPrevious {
+		slices.Reverse(content)
+		slices.Reverse(values)
+	}
+
+	result := &types.CursorPage[T]{Content: content}
+	if len(content) == 0 {
+		return result, nil
+	}
+
+	if (current.Direction == cursorNext && hasMore) || current.Direction == cursorPrevious {
+		if result.NextCursor, err = encodeCursor(cursor{values[len(values)-1], cursorNext}); err != nil {
+			return nil, err
+		}
+	}
+
+	if (current.Direction == cursorNext && current.Values != nil) || (current.Direction == cursorPrevious && hasMore) {
+		if result.PreviousCursor, err = encodeCursor(cursor{values[0], cursorPrevious}); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// validate checks if the CursorQuery instance is initialized, if the page and its order are empty or invalid,
+// if the order fields are columns, and if the query is empty.
+//
+// instance: the database instance to validate against
+// Returns an error.
+func (q *CursorQuery[T]) validate(instance *sql.DB) error {
+	if instance == nil {
+		return errors.New(db_not_initialized_error)
+	}
+
+	if q.page == nil {
+		return errors.New(page_is_empty_error)
+	}
+
+	if len(q.page.Order) == 0 {
+		return errors.New(cursor_order_is_empty_error)
+	}
+
+	if err := q.page.Validate(); err != nil {
+		return err
+	}
+
+	for _, order := range q.page.Order {
+		if !cursorColumnPattern.MatchString(order.Expression()) {
+			return fmt.Errorf(cursor_order_expression_error, order.Field, order.Expression())
+		}
+	}
+
+	if q.query == "" {
+		return errors.New(query_is_empty_error)
+	}
+
+	return nil
+}
+
+// fetch executes the cursor query on the provided SQL instance, fetching one row more than the page size
+// to know if there are more rows.
+//
+// instance: The *sql.DB instance to execute the query.
+// current: the cursor of the requested page.
+// Returns the content, the order fields values of each row and an error.
+func (q *CursorQuery[T]) fetch(instance *sql.DB, current cursor) (content []T, values [][]any, err error) {
+	condition, args := cursorCondition(dialectOf(instance), q.page.Order, current, q.args)
+	query := fmt.Sprintf(cursorDataQuery, q.query, condition, cursorOrder(q.page.Order, current.Direction), int(q.page.Size)+1)
+
+	ctx, finish := startQueryHooks(q.ctx, CURSOR_QUERY_OPERATION, query, args)
+	defer func() { finish(int64(len(content)), err) }()
+
+	rows, err := q.queryContext(ctx, instance, query, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closer(rows)
+
+	return q.scan(rows)
+}
+
+// queryContext executes the query on the provided SQL instance, or in the transaction of the context.
+//
+// ctx: the context of the execution, returned by the query hooks.
+// instance: The *sql.DB instance to execute the query.
+// query: the cursor query.
+// args: the query args with the cursor values.
+// Returns the resulting rows and an error.
+func (q *CursorQuery[T]) queryContext(ctx context.Context, instance *sql.DB, query string, args []any) (*sql.Rows, error) {
+	if tx, ok := transactionOf(ctx, instance); ok {
+		return tx.QueryContext(ctx, query, args...)
+	}
+
+	return instance.QueryContext(ctx, query, args...)
+}
+
+// scan scans the rows into the content and the values of the order fields of each row, which must not be NULL.
+//
+// rows: the query result rows
+// Returns the content, the order fields values of each row and an error.
+func (q *CursorQuery[T]) scan(rows *sql.Rows) ([]T, [][]any, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orderColumns := make([]int, 0, len(q.page.Order))
+	for _, order := range q.page.Order {
+		column := slices.IndexFunc(columns, func(column string) bool { return strings.EqualFold(column, cursorColumn(order.Expression())) })
+		if column < 0 {
+			return nil, nil, fmt.Errorf(cursor_order_column_error, order.Field)
+		}
+		orderColumns = append(orderColumns, column)
+	}
+
+	mapper, err := newRowMapper(reflect.TypeOf((*T)(nil)).Elem(), columns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, values := make([]T, 0), make([][]any, 0)
+	for rows.Next() {
+		model := new(T)
+		dest := mapper.destinations(reflect.ValueOf(model).Elem())
+		if err = rows.Scan(dest...); err != nil {
+			return nil, nil, err
+		}
+
+		rowValues := make([]any, 0, len(orderColumns))
+		for i, column := range orderColumns {
+			value, err := cursorValue(dest[column])
+			if err != nil {
+				return nil, nil, err
+			}
+			if value == nil {
+				return nil, nil, fmt.Errorf(cursor_order_null_error, q.page.Order[i].Field)
+			}
+			rowValues = append(rowValues, value)
+		}
+
+		content, values = append(content, *model), append(values, rowValues)
+	}
+
+	return content, values, rows.Err()
+}
+
+// cursorCondition returns the WHERE clause that filters the rows after (or before, for the previous page) the cursor values,
+// and the query args with the cursor values appended. Mixed sort directions are expanded into
+// `a > $1 OR (a = $1 AND b < $2)`.
+//
+// dialect: the dialect of the database
+// order: the order fields of the query
+// current: the cursor of the requested page
+// args: the query args
+// Returns the WHERE clause and the args.
+func cursorCondition(dialect Dialect, order []types.Sort, current cursor, args []any) (string, []any) {
+	if current.Values == nil {
+		return "", args
+	}
+
+	args = append(slices.Clip(args), current.Values...)
+	first := len(args) - len(current.Values) + 1
+
+	conditions := make([]string, 0, len(order))
+	for i, sort := range order {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("tb.%s = %s", cursorColumn(order[j].Expression()), dialect.Placeholder(first+j)))
+		}
+
+		operator := ">"
+		if (sort.Direction == types.DESC) != (current.Direction == cursorPrevious) {
+			operator = "<"
+		}
+		terms = append(terms, fmt.Sprintf("tb.%s %s %s", cursorColumn(sort.Expression()), operator, dialect.Placeholder(first+i)))
+		conditions = append(conditions, "("+strings.Join(terms, " AND ")+")")
+	}
+
+	return " WHERE " + strings.Join(conditions, " OR "), args
+}
+
+// cursorOrder returns the ORDER BY clause of the cursor query, reversed for the previous page.
+//
+// order: the order fields of the query
+// direction: the cursor direction
+// Returns the ORDER BY clause.
+func cursorOrder(order []types.Sort, direction string) string {
+	orders := make([]string, 0, len(order))
+	for _, sort := range order {
+		sortDirection := sort.Direction
+		if direction == cursorPrevious {
+			sortDirection = types.DESC
+			if sort.Direction == types.DESC {
+				sortDirection = types.ASC
+			}
+		}
+		orders = append(orders, fmt.Sprintf("tb.%s %s", cursorColumn(sort.Expression()), sortDirection))
+	}
+
+	return strings.Join(orders, ", ")
+}
+
+// cursorColumn returns the column name of an order field, removing the table alias, ex: u.name -> name.
+func cursorColumn(field string) string {
+	return field[strings.LastIndex(field, ".")+1:]
+}
+
+// cursorValue returns the value of a scan destination to be stored in the cursor.
+//
+// dest: the scan destination pointer
+// Returns the value and an error.
+func cursorValue(dest any) (any, error) {
+	value := reflect.ValueOf(dest).Elem()
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+
+	result := value.Interface()
+	if valuer, ok := result.(driver.Valuer); ok {
+		var err error
+		if result, err = valuer.Value(); err != nil {
+			return nil, err
+		}
+	}
+
+	if bytes, ok := result.([]byte); ok {
+		return string(bytes), nil
+	}
+
+	return result, nil
+}
+
+// encodeCursor encodes the cursor into an opaque string.
+func encodeCursor(c cursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor decodes the opaque cursor string. An empty string is the cursor of the first page.
+//
+// value: the opaque cursor string
+// size: the number of order fields
+// Returns the cursor and an error when the cursor is invalid.
+func decodeCursor(value string, size int) (cursor, error) {
+	c := cursor{Direction: cursorNext}
+	if value == "" {
+		return c, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return c, fmt.Errorf(cursor_invalid_error, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&c); err != nil {
+		return c, fmt.Errorf(cursor_invalid_error, err)
+	}
+
+	if c.Direction != cursorNext && c.Direction != cursorPrevious {
+		return c, fmt.Errorf(cursor_invalid_error, fmt.Errorf("unknown direction %q", c.Direction))
+	}
+
+	if len(c.Values) != size {
+		return c, fmt.Errorf(cursor_values_mismatch_error, size, len(c.Values))
+	}
+
+	return c, nil
+}
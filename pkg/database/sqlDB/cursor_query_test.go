@@ -0,0 +1,166 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorQueryWithoutInitialize(t *testing.T) {
+	ctx := context.Background()
+	page := types.NewPageRequest(1, 1, []types.Sort{types.NewSort(types.ASC, "id")})
+
+	t.Run("Should return error when execute cursor query with db not initialized error", func(t *testing.T) {
+		sqlDBInstance = nil
+		result, err := NewCursorQuery[User](ctx, page, "", query_base).Execute()
+
+		assert.EqualError(t, err, db_not_initialized_error)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should return error when execute cursor query without order", func(t *testing.T) {
+		instance, _ := sql.Open("postgres", "")
+		result, err := NewCursorQuery[User](ctx, types.NewPageRequest(1, 1, nil), "", query_base).ExecuteInInstance(instance)
+
+		assert.EqualError(t, err, cursor_order_is_empty_error)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should return error when execute cursor query with order expression", func(t *testing.T) {
+		instance, _ := sql.Open("postgres", "")
+		sort, _ := types.SortableFields{"name": "LOWER(u.name)"}.NewSort(types.ASC, "name")
+		result, err := NewCursorQuery[User](ctx, types.NewPageRequest(1, 1, []types.Sort{sort}), "", query_base).ExecuteInInstance(instance)
+
+		assert.EqualError(t, err, fmt.Sprintf(cursor_order_expression_error, "name", "LOWER(u.name)"))
+		assert.Nil(t, result)
+	})
+}
+
+func TestCursor(t *testing.T) {
+	order := []types.Sort{types.NewSort(types.DESC, "u.name"), types.NewSort(types.ASC, "id")}
+
+	t.Run("Should encode and decode cursor", func(t *testing.T) {
+		encoded, err := encodeCursor(cursor{[]any{"ADMIN USER", 1}, cursorNext})
+		assert.NoError(t, err)
+
+		result, err := decodeCursor(encoded, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, cursor{[]any{"ADMIN USER", json.Number("1")}, cursorNext}, result)
+	})
+
+	t.Run("Should decode empty cursor as first page", func(t *testing.T) {
+		result, err := decodeCursor("", 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, cursor{Direction: cursorNext}, result)
+	})
+
+	t.Run("Should return error when cursor is invalid", func(t *testing.T) {
+		_, err := decodeCursor("invalid cursor", 1)
+		assert.ErrorContains(t, err, "invalid cursor")
+
+		encoded, _ := encodeCursor(cursor{[]any{1}, "other"})
+		_, err = decodeCursor(encoded, 1)
+		assert.ErrorContains(t, err, "invalid cursor")
+
+		encoded, _ = encodeCursor(cursor{[]any{1}, cursorNext})
+		_, err = decodeCursor(encoded, 2)
+		assert.EqualError(t, err, fmt.Sprintf(cursor_values_mismatch_error, 2, 1))
+	})
+
+	t.Run("Should build next page condition and order", func(t *testing.T) {
+		condition, args := cursorCondition(Postgres, order, cursor{[]any{"ADMIN USER", 1}, cursorNext}, []any{100})
+
+		assert.Equal(t, " WHERE (tb.name < $2) OR (tb.name = $2 AND tb.id > $3)", condition)
+		assert.Equal(t, []any{100, "ADMIN USER", 1}, args)
+		assert.Equal(t, "tb.name DESC, tb.id ASC", cursorOrder(order, cursorNext))
+	})
+
+	t.Run("Should build previous page condition and reversed order", func(t *testing.T) {
+		condition, args := cursorCondition(Postgres, order, cursor{[]any{"ADMIN USER", 1}, cursorPrevious}, nil)
+
+		assert.Equal(t, " WHERE (tb.name > $1) OR (tb.name = $1 AND tb.id < $2)", condition)
+		assert.Equal(t, []any{"ADMIN USER", 1}, args)
+		assert.Equal(t, "tb.name ASC, tb.id DESC", cursorOrder(order, cursorPrevious))
+	})
+
+	t.Run("Should not filter the first page", func(t *testing.T) {
+		condition, args := cursorCondition(Postgres, order, cursor{Direction: cursorNext}, []any{100})
+
+		assert.Empty(t, condition)
+		assert.Equal(t, []any{100}, args)
+	})
+
+	t.Run("Should return cursor values of scan destinations", func(t *testing.T) {
+		name := "name"
+		namePointer := &name
+		var nilPointer *string
+
+		for dest, expected := range map[any]any{
+			&name:                                 "name",
+			&namePointer:                          "name",
+			&nilPointer:                           nil,
+			&sql.NullInt64{Int64: 1, Valid: true}: int64(1),
+			&sql.NullInt64{}:                      nil,
+		} {
+			value, err := cursorValue(dest)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value)
+		}
+
+		var raw any = []byte("raw")
+		value, err := cursorValue(&raw)
+		assert.NoError(t, err)
+		assert.Equal(t, "raw", value)
+	})
+}
+
+func TestCursorQuery(t *testing.T) {
+	InitializeSqlDBTest()
+	ctx := context.Background()
+	const query = "SELECT u.id, u.name, u.birthday FROM users u"
+	page := types.NewPageRequest(1, 1, []types.Sort{types.NewSort(types.DESC, "u.name"), types.NewSort(types.ASC, "u.id")})
+
+	t.Run("Should return error when order field is not a column of the query", func(t *testing.T) {
+		page := types.NewPageRequest(1, 1, []types.Sort{types.NewSort(types.ASC, "profile_id")})
+		result, err := NewCursorQuery[User](ctx, page, "", query).Execute()
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should navigate pages forward and backward", func(t *testing.T) {
+		first, err := NewCursorQuery[User](ctx, page, "", query).Execute()
+		assert.NoError(t, err)
+		assert.Len(t, first.Content, 1)
+		assert.Equal(t, "OTHER USER", first.Content[0].Name)
+		assert.True(t, first.HasNext())
+		assert.False(t, first.HasPrevious())
+
+		second, err := NewCursorQuery[User](ctx, page, first.NextCursor, query).Execute()
+		assert.NoError(t, err)
+		assert.Len(t, second.Content, 1)
+		assert.Equal(t, "ADMIN USER", second.Content[0].Name)
+		assert.False(t, second.HasNext())
+		assert.True(t, second.HasPrevious())
+
+		previous, err := NewCursorQuery[User](ctx, page, second.PreviousCursor, query).Execute()
+		assert.NoError(t, err)
+		assert.Equal(t, first.Content, previous.Content)
+		assert.True(t, previous.HasNext())
+		assert.False(t, previous.HasPrevious())
+	})
+
+	t.Run("Should filter with query params", func(t *testing.T) {
+		result, err := NewCursorQuery[User](ctx, page, "", query+" WHERE u.name = $1", "ADMIN USER").Execute()
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Content, 1)
+		assert.False(t, result.HasNext())
+	})
+}
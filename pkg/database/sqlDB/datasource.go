@@ -0,0 +1,69 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
+)
+
+// SqlDatasourceContextKey is the type of the context key for the datasource name.
+type SqlDatasourceContextKey string
+
+const (
+	SqlDatasourceContext SqlDatasourceContextKey = "SqlDatasourceContext"
+)
+
+// datasources contains the named sql database instances, by upper case name.
+var datasources = map[string]*sql.DB{}
+
+// initializeDatasources starts the connection with all named datasources configured and executes their migrations.
+//
+// No parameters.
+// No return values.
+func initializeDatasources() {
+	for name, datasource := range config.SQL_DB_DATASOURCES {
+		instance := NewSQLDatabaseInstance(name, datasource.ConnectionURI())
+		instance.SetMaxOpenConns(datasource.MaxOpenConns)
+		instance.SetMaxIdleConns(datasource.MaxIdleConns)
+
+		if err := executeDatabaseMigration(instance, datasourceMigrationConfig(datasource)); err != nil {
+			logging.Fatal(db_migration_error, err)
+		}
+
+		datasources[name] = instance
+		initializeReplicas(name, instance, datasource.ReplicaConnectionURIs(), datasource.MaxOpenConns, datasource.MaxIdleConns)
+	}
+}
+
+// GetDatasource returns the named datasource instance, or nil if it is not initialized.
+//
+// name: the datasource name, ex: REPORTING for the SQL_DB_REPORTING_* env variables.
+// Returns a pointer to sql.DB.
+func GetDatasource(name string) *sql.DB {
+	return datasources[strings.ToUpper(name)]
+}
+
+// WithDatasource returns a copy of ctx that binds the queries, statements and transactions to the named datasource.
+// Inside a transaction of another datasource they are executed out of the transaction.
+//
+// ctx: the parent context.
+// name: the datasource name, ex: REPORTING for the SQL_DB_REPORTING_* env variables, or empty for the default datasource.
+// Returns a context.Context.
+func WithDatasource(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, SqlDatasourceContext, name)
+}
+
+// getInstance returns the datasource instance bound to the context, or the default instance.
+//
+// ctx: the context of the query, statement or transaction.
+// Returns a pointer to sql.DB.
+func getInstance(ctx context.Context) *sql.DB {
+	if name, ok := ctx.Value(SqlDatasourceContext).(string); ok && name != "" {
+		return GetDatasource(name)
+	}
+
+	return sqlDBInstance
+}
@@ -0,0 +1,46 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatasource(t *testing.T) {
+	ctx := context.Background()
+	reporting, err := sql.Open("postgres", "host=localhost dbname=reporting sslmode=disable")
+	assert.NoError(t, err)
+	datasources["REPORTING"] = reporting
+	defer delete(datasources, "REPORTING")
+
+	t.Run("Should return named datasource instance", func(t *testing.T) {
+		assert.Equal(t, reporting, GetDatasource("REPORTING"))
+		assert.Equal(t, reporting, GetDatasource("reporting"))
+		assert.Nil(t, GetDatasource("not-found"))
+	})
+
+	t.Run("Should return default instance when context is not bound to a datasource", func(t *testing.T) {
+		assert.Equal(t, sqlDBInstance, getInstance(ctx))
+	})
+
+	t.Run("Should return datasource instance bound to the context", func(t *testing.T) {
+		assert.Equal(t, reporting, getInstance(WithDatasource(ctx, "reporting")))
+	})
+
+	t.Run("Should return default instance when context is bound to the default datasource", func(t *testing.T) {
+		assert.Equal(t, sqlDBInstance, getInstance(WithDatasource(WithDatasource(ctx, "reporting"), "")))
+	})
+
+	t.Run("Should return error when context is bound to a datasource not initialized", func(t *testing.T) {
+		ctx := WithDatasource(ctx, "not-found")
+
+		result, err := NewQuery[User](ctx, query_base).Many()
+		assert.EqualError(t, err, db_not_initialized_error)
+		assert.Nil(t, result)
+
+		assert.EqualError(t, NewStatement(ctx, "DELETE FROM users").Execute(), db_not_initialized_error)
+		assert.EqualError(t, NewTransaction().Execute(ctx, func(ctx context.Context) error { return nil }), db_not_initialized_error)
+	})
+}
@@ -0,0 +1,119 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/lib/pq"
+)
+
+const (
+	postgresDialectName string = "postgres"
+
+	pageTotalPostgresQuery string = "SELECT COUNT(tb.*) FROM (%s) tb"
+	pageDataPostgresQuery  string = "%s ORDER BY %s LIMIT %d OFFSET %d"
+)
+
+// Dialect is the SQL syntax and the driver behavior of a database used by the generated queries,
+// the array arguments, the bulk insert and the migrations.
+type Dialect interface {
+	// Name returns the dialect name, ex: postgres.
+	Name() string
+	// Placeholder returns the positional parameter placeholder, ex: $1. The position starts at 1.
+	Placeholder(position int) string
+	// CountQuery returns the query that counts the rows of the query.
+	CountQuery(query string) string
+	// PageQuery returns the query sorted by the order with the limit and offset of the page.
+	PageQuery(query string, order string, limit, offset int) string
+	// ArrayArgument returns the slice wrapped to be used as statement argument.
+	ArrayArgument(value any) any
+	// CopyIn returns the bulk copy statement of the table columns, or false when the dialect has no bulk copy.
+	CopyIn(table string, columns []string) (string, bool)
+	// MigrationDriver returns the golang-migrate database driver of the instance, and the closer of its resources or nil.
+	MigrationDriver(ctx context.Context, instance *sql.DB, databaseName string) (database.Driver, io.Closer, error)
+}
+
+// Postgres is the PostgreSQL dialect, the default dialect of the instances.
+var Postgres Dialect = postgresDialect{}
+
+// dialects contains the dialects of the instances that are not PostgreSQL.
+var dialects sync.Map
+
+// SetDialect sets the dialect of an instance.
+//
+// instance: the sql database instance.
+// dialect: the dialect of the instance.
+func SetDialect(instance *sql.DB, dialect Dialect) {
+	dialects.Store(instance, dialect)
+}
+
+// dialectOf returns the dialect of the instance, PostgreSQL when it's not set.
+//
+// instance: the sql database instance.
+// Returns the Dialect.
+func dialectOf(instance *sql.DB) Dialect {
+	if dialect, ok := dialects.Load(instance); ok {
+		return dialect.(Dialect)
+	}
+
+	return Postgres
+}
+
+// postgresDialect implements the Dialect of PostgreSQL.
+type postgresDialect struct{}
+
+// Name returns postgres.
+func (postgresDialect) Name() string {
+	return postgresDialectName
+}
+
+// Placeholder returns $<position>.
+func (postgresDialect) Placeholder(position int) string {
+	return fmt.Sprintf("$%d", position)
+}
+
+// CountQuery returns the query counting the rows of the query as subquery.
+func (postgresDialect) CountQuery(query string) string {
+	return fmt.Sprintf(pageTotalPostgresQuery, query)
+}
+
+// PageQuery returns the query with ORDER BY, LIMIT and OFFSET.
+func (postgresDialect) PageQuery(query string, order string, limit, offset int) string {
+	return fmt.Sprintf(pageDataPostgresQuery, query, order, limit, offset)
+}
+
+// ArrayArgument wraps the slice with pq.Array.
+func (postgresDialect) ArrayArgument(value any) any {
+	return pq.Array(value)
+}
+
+// CopyIn returns the COPY statement of the table, with schema when the table name has one.
+func (postgresDialect) CopyIn(table string, columns []string) (string, bool) {
+	if schema, name, found := strings.Cut(table, "."); found {
+		return pq.CopyInSchema(schema, name, columns...), true
+	}
+
+	return pq.CopyIn(table, columns...), true
+}
+
+// MigrationDriver returns the postgres migration driver with a dedicated connection of the instance.
+func (postgresDialect) MigrationDriver(ctx context.Context, instance *sql.DB, databaseName string) (database.Driver, io.Closer, error) {
+	conn, err := instance.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{DatabaseName: databaseName})
+	if err != nil {
+		closer(conn)
+		return nil, nil, err
+	}
+
+	return driver, conn, nil
+}
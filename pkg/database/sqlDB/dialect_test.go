@@ -0,0 +1,40 @@
+package sqlDB
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresDialect(t *testing.T) {
+	t.Run("Should return postgres syntax", func(t *testing.T) {
+		assert.Equal(t, "postgres", Postgres.Name())
+		assert.Equal(t, "$2", Postgres.Placeholder(2))
+		assert.Equal(t, "SELECT COUNT(tb.*) FROM (SELECT * FROM users) tb", Postgres.CountQuery("SELECT * FROM users"))
+		assert.Equal(t, "SELECT * FROM users ORDER BY name ASC LIMIT 10 OFFSET 20", Postgres.PageQuery("SELECT * FROM users", "name ASC", 10, 20))
+		assert.Equal(t, pq.Array([]string{"a"}), Postgres.ArrayArgument([]string{"a"}))
+	})
+
+	t.Run("Should return copy statement with schema", func(t *testing.T) {
+		query, isCopy := Postgres.CopyIn("public.users", []string{"name"})
+
+		assert.True(t, isCopy)
+		assert.Equal(t, pq.CopyInSchema("public", "users", "name"), query)
+	})
+}
+
+func TestDialectOf(t *testing.T) {
+	t.Run("Should return postgres when the instance has no dialect", func(t *testing.T) {
+		assert.Equal(t, Postgres, dialectOf(nil))
+		assert.Equal(t, Postgres, dialectOf(&sql.DB{}))
+	})
+
+	t.Run("Should return the dialect of the instance", func(t *testing.T) {
+		instance := &sql.DB{}
+		SetDialect(instance, postgresDialect{})
+
+		assert.Equal(t, postgresDialect{}, dialectOf(instance))
+	})
+}
@@ -0,0 +1,163 @@
+package sqlDB
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/observer"
+)
+
+const (
+	leaderElectionInterval time.Duration = 5 * time.Second
+
+	leader_election_error string = "leader election %s error: %v"
+	leader_unlock_error   string = "could not release leadership %s: %v"
+)
+
+// LeaderCallbacks contains the functions called when the leadership is gained or lost.
+type LeaderCallbacks struct {
+	// OnElected is called when the replica becomes the leader, with a context canceled when the leadership is lost.
+	// It must not block, the leader work should run in a goroutine.
+	OnElected func(ctx context.Context)
+	// OnRevoked is called when the replica is no longer the leader, including when the elector is closed.
+	OnRevoked func()
+}
+
+// LeaderElector keeps one replica as leader holding the session advisory lock of a key.
+// The replicas try to acquire the lock in each interval, and the leader checks its connection is alive,
+// because the lock is released by PostgreSQL when the session ends.
+type LeaderElector struct {
+	key       string
+	interval  time.Duration
+	callbacks LeaderCallbacks
+	leader    atomic.Bool
+	lock      *AdvisoryLock
+	cancel    context.CancelFunc
+	stop      chan struct{}
+	done      chan struct{}
+	once      sync.Once
+}
+
+// NewLeaderElector starts the leader election of the key in the datasource bound to the context.
+// The leadership is released when the context is done or the application is closed. The transaction of the context
+// is ignored, since the leadership is held by a session lock that outlives the transaction.
+//
+// ctx: the context of the election.
+// key: the election key, ex: the name of the background job.
+// callbacks: the functions called when the leadership is gained or lost.
+// Returns a pointer to LeaderElector and an error.
+func NewLeaderElector(ctx context.Context, key string, callbacks LeaderCallbacks) (*LeaderElector, error) {
+	if key == "" {
+		return nil, errors.New(lock_key_is_empty_error)
+	}
+
+	if getInstance(ctx) == nil {
+		return nil, errors.New(db_not_initialized_error)
+	}
+
+	e := &LeaderElector{
+		key:       key,
+		interval:  leaderElectionInterval,
+		callbacks: callbacks,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go e.run(withoutTransaction(ctx))
+
+	observer.Attach(e)
+	return e, nil
+}
+
+// IsLeader checks if the replica is the leader.
+//
+// No parameters.
+// Returns true when the replica holds the leadership.
+func (e *LeaderElector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Close stops the election, releasing the leadership.
+func (e *LeaderElector) Close() {
+	e.once.Do(func() {
+		logging.Info("closing leader election %s", e.key)
+		close(e.stop)
+		<-e.done
+	})
+}
+
+// run campaigns in each interval until the election is stopped or the context is done.
+func (e *LeaderElector) run(ctx context.Context) {
+	defer close(e.done)
+	defer e.revoke()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(ctx)
+
+		select {
+		case <-e.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign acquires the leadership when it's free, or checks the leadership is still held.
+func (e *LeaderElector) campaign(ctx context.Context) {
+	if e.IsLeader() {
+		if err := e.lock.ping(ctx); err != nil {
+			logging.Error(leader_election_error, e.key, err)
+			e.revoke()
+		}
+		return
+	}
+
+	lock, err := TryLock(context.WithoutCancel(ctx), e.key)
+	if err != nil {
+		if !errors.Is(err, ErrLockNotAcquired) {
+			logging.Error(leader_election_error, e.key, err)
+		}
+		return
+	}
+
+	e.elect(ctx, lock)
+}
+
+// elect sets the replica as leader and calls OnElected.
+func (e *LeaderElector) elect(ctx context.Context, lock *AdvisoryLock) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	e.lock = lock
+	e.cancel = cancel
+	e.leader.Store(true)
+
+	logging.Info("elected leader of %s", e.key)
+	if e.callbacks.OnElected != nil {
+		e.callbacks.OnElected(leaderCtx)
+	}
+}
+
+// revoke releases the leadership, when the replica is the leader, and calls OnRevoked.
+func (e *LeaderElector) revoke() {
+	if !e.leader.Swap(false) {
+		return
+	}
+
+	e.cancel()
+	if err := e.lock.Unlock(); err != nil {
+		logging.Warn(leader_unlock_error, e.key, err)
+	}
+	e.lock = nil
+
+	logging.Info("revoked leader of %s", e.key)
+	if e.callbacks.OnRevoked != nil {
+		e.callbacks.OnRevoked()
+	}
+}
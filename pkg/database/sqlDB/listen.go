@@ -0,0 +1,204 @@
+package sqlDB
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/monitoring"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/observer"
+	"github.com/lib/pq"
+)
+
+const (
+	notifyQuery string = "SELECT pg_notify($1, $2)"
+
+	listenerMinReconnectInterval time.Duration = time.Second
+	listenerMaxReconnectInterval time.Duration = time.Minute
+	listenerPingInterval         time.Duration = 90 * time.Second
+
+	listen_transaction            string = "LISTEN %s"
+	channel_is_empty_error        string = "channel is empty"
+	datasource_not_found_error    string = "datasource %s is not configured"
+	listen_error                  string = "could not listen channel %s: %w"
+	listen_payload_decode_error   string = "could not decode notification payload of channel %s: %w"
+	listen_handler_error          string = "could not handle notification of channel %s: %v"
+	listen_connection_event_error string = "listener of channel %s connection error: %v"
+)
+
+// Listener receives the notifications of a PostgreSQL channel with LISTEN.
+// The connection is reestablished automatically, and the notifications sent while disconnected are lost.
+type Listener struct {
+	channel  string
+	listener *pq.Listener
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+// Listen starts listening a PostgreSQL channel in a dedicated connection of the datasource bound to the context,
+// calling the handler with the notification payload decoded from JSON into T. A string T receives the raw payload.
+// The listener stops when the context is done or the application is closed.
+//
+// ctx: the context of the listener and the handler calls.
+// channel: the channel name.
+// handler: the function called with each notification payload.
+// Returns a pointer to Listener and an error.
+func Listen[T any](ctx context.Context, channel string, handler func(ctx context.Context, payload T) error) (*Listener, error) {
+	if channel == "" {
+		return nil, errors.New(channel_is_empty_error)
+	}
+
+	connectionURI, err := listenConnectionURI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Listener{channel: channel, stop: make(chan struct{}), done: make(chan struct{})}
+	l.listener = pq.NewListener(connectionURI, listenerMinReconnectInterval, listenerMaxReconnectInterval, l.logEvent)
+	if err = l.listener.Listen(channel); err != nil {
+		closer(l.listener)
+		return nil, fmt.Errorf(listen_error, channel, err)
+	}
+
+	go l.run(ctx, func(ctx context.Context, extra string) error {
+		payload, err := decodePayload[T](extra)
+		if err != nil {
+			return fmt.Errorf(listen_payload_decode_error, channel, err)
+		}
+		return handler(ctx, payload)
+	})
+
+	observer.Attach(l)
+	return l, nil
+}
+
+// Notify sends a notification to a PostgreSQL channel with the payload encoded as JSON, or the raw payload when it's a string.
+// Inside a transaction the notification is delivered only when the transaction is committed.
+//
+// ctx: the context of the statement.
+// channel: the channel name.
+// payload: the notification payload.
+// Returns an error.
+func Notify(ctx context.Context, channel string, payload any) error {
+	if channel == "" {
+		return errors.New(channel_is_empty_error)
+	}
+
+	extra, err := encodePayload(payload)
+	if err != nil {
+		return err
+	}
+
+	return NewStatement(ctx, notifyQuery, channel, extra).Execute()
+}
+
+// Close stops the listener, waiting for the notification in progress, and closes its connection.
+func (l *Listener) Close() {
+	l.once.Do(func() {
+		logging.Info("closing listener of channel %s", l.channel)
+		close(l.stop)
+		<-l.done
+		closer(l.listener)
+	})
+}
+
+// run calls the handler with each notification until the listener is stopped or the context is done.
+// The connection is checked with ping when there is no notification in the ping interval.
+func (l *Listener) run(ctx context.Context, handle func(ctx context.Context, extra string) error) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ctx.Done():
+			go l.Close()
+			return
+		case notification := <-l.listener.Notify:
+			// a nil notification is sent after the connection is reestablished
+			if notification != nil {
+				l.handle(ctx, notification.Extra, handle)
+			}
+		case <-ticker.C:
+			go l.listener.Ping()
+		}
+	}
+}
+
+// handle calls the handler in a monitoring transaction, logging its error.
+func (l *Listener) handle(ctx context.Context, extra string, handle func(ctx context.Context, extra string) error) {
+	txn, ctx := monitoring.StartTransaction(ctx, fmt.Sprintf(listen_transaction, l.channel))
+	defer monitoring.EndTransaction(txn)
+
+	if err := handle(ctx, extra); err != nil {
+		logging.Error(listen_handler_error, l.channel, err)
+		monitoring.NoticeError(txn, err)
+	}
+}
+
+// logEvent logs the connection errors of the listener.
+func (l *Listener) logEvent(event pq.ListenerEventType, err error) {
+	if err != nil {
+		logging.Error(listen_connection_event_error, l.channel, err)
+	}
+
+	if event == pq.ListenerEventReconnected {
+		logging.Info("listener of channel %s reconnected", l.channel)
+	}
+}
+
+// listenConnectionURI returns the connection URI of the datasource bound to the context, or of the default instance.
+//
+// ctx: the context of the listener.
+// Returns the connection URI and an error.
+func listenConnectionURI(ctx context.Context) (string, error) {
+	name, ok := ctx.Value(SqlDatasourceContext).(string)
+	if !ok {
+		return config.SQL_DB_CONNECTION_URI, nil
+	}
+
+	datasource, ok := config.SQL_DB_DATASOURCES[strings.ToUpper(name)]
+	if !ok {
+		return "", fmt.Errorf(datasource_not_found_error, name)
+	}
+
+	return datasource.ConnectionURI(), nil
+}
+
+// encodePayload encodes the notification payload as JSON, keeping the strings raw.
+//
+// payload: the notification payload.
+// Returns the encoded payload and an error.
+func encodePayload(payload any) (string, error) {
+	if extra, ok := payload.(string); ok {
+		return extra, nil
+	}
+
+	data, err := json.Marshal(payload)
+	return string(data), err
+}
+
+// decodePayload decodes the notification payload from JSON into T, keeping the raw payload when T is a string.
+//
+// extra: the notification payload.
+// Returns the decoded T and an error.
+func decodePayload[T any](extra string) (T, error) {
+	var payload T
+	if raw, ok := any(&payload).(*string); ok {
+		*raw = extra
+		return payload, nil
+	}
+
+	err := json.Unmarshal([]byte(extra), &payload)
+	return payload, err
+}
@@ -0,0 +1,125 @@
+package sqlDB
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type notificationTest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func TestListenPayload(t *testing.T) {
+	t.Run("Should return error when channel is empty", func(t *testing.T) {
+		listener, err := Listen(context.Background(), "", func(context.Context, string) error { return nil })
+
+		assert.EqualError(t, err, channel_is_empty_error)
+		assert.Nil(t, listener)
+		assert.EqualError(t, Notify(context.Background(), "", "payload"), channel_is_empty_error)
+	})
+
+	t.Run("Should return error when datasource is not configured", func(t *testing.T) {
+		listener, err := Listen(WithDatasource(context.Background(), "unknown"), "users", func(context.Context, string) error { return nil })
+
+		assert.EqualError(t, err, "datasource unknown is not configured")
+		assert.Nil(t, listener)
+	})
+
+	t.Run("Should encode and decode JSON payload", func(t *testing.T) {
+		extra, err := encodePayload(notificationTest{"User", "user@email.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"User","email":"user@email.com"}`, extra)
+
+		payload, err := decodePayload[notificationTest](extra)
+		assert.NoError(t, err)
+		assert.Equal(t, notificationTest{"User", "user@email.com"}, payload)
+	})
+
+	t.Run("Should keep raw string payload", func(t *testing.T) {
+		extra, err := encodePayload("user updated")
+		assert.NoError(t, err)
+		assert.Equal(t, "user updated", extra)
+
+		payload, err := decodePayload[string](extra)
+		assert.NoError(t, err)
+		assert.Equal(t, "user updated", payload)
+	})
+
+	t.Run("Should return error when payload is not JSON", func(t *testing.T) {
+		_, err := decodePayload[notificationTest]("user updated")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestListen(t *testing.T) {
+	ctx := context.Background()
+	InitializeSqlDBTest()
+
+	t.Run("Should receive the notification decoded", func(t *testing.T) {
+		received := make(chan notificationTest)
+		listener, err := Listen(ctx, "listen_users", func(_ context.Context, payload notificationTest) error {
+			received <- payload
+			return nil
+		})
+		assert.NoError(t, err)
+		defer listener.Close()
+
+		assert.NoError(t, Notify(ctx, "listen_users", notificationTest{"User", "user@email.com"}))
+
+		select {
+		case payload := <-received:
+			assert.Equal(t, notificationTest{"User", "user@email.com"}, payload)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test didn't finish after 5s")
+		}
+	})
+
+	t.Run("Should deliver the notification only when the transaction is committed", func(t *testing.T) {
+		received := make(chan string, 2)
+		listener, err := Listen(ctx, "listen_transaction", func(_ context.Context, payload string) error {
+			received <- payload
+			return nil
+		})
+		assert.NoError(t, err)
+		defer listener.Close()
+
+		err = NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if err := Notify(ctx, "listen_transaction", "rollback"); err != nil {
+				return err
+			}
+			return errors.New("rollback")
+		})
+		assert.EqualError(t, err, "rollback")
+
+		assert.NoError(t, NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			return Notify(ctx, "listen_transaction", "commit")
+		}))
+
+		select {
+		case payload := <-received:
+			assert.Equal(t, "commit", payload)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test didn't finish after 5s")
+		}
+	})
+
+	t.Run("Should stop the listener when the context is done", func(t *testing.T) {
+		listenerCtx, cancel := context.WithCancel(ctx)
+		listener, err := Listen(listenerCtx, "listen_cancel", func(context.Context, string) error { return nil })
+		assert.NoError(t, err)
+
+		cancel()
+
+		select {
+		case <-listener.done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test didn't finish after 5s")
+		}
+	})
+}
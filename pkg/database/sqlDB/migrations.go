@@ -1,15 +1,22 @@
 package sqlDB
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
 	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
 const (
@@ -17,47 +24,299 @@ const (
 	migrationWithPwdDefaultPath string = "${PWD}/migrations"
 	migrationDefaultPath        string = "./migrations"
 
-	migrationIgnoringMsg              string = "Ignoring migration because env variable SQL_DB_MIGRATION is set to false"
+	migrationIgnoringMsg              string = "Ignoring %s migration because env variable %s is set to false"
 	migrationEnvNotSetUsingDefaultMsg string = "Migration env variable %s is not set, using default value %s"
 	migrationStartingMsg              string = "Starting migration execution"
 	migrationCouldNotConnectDBMsg     string = "Could not connect to database for migration: %v"
 	migrationExecutingInfoMsg         string = "Executing migration on path: %s"
 	migrationExecutionWithErrorMsg    string = "An error when executing database migration: %v"
 	migrationFinalizedMsg             string = "Migration finalized successfully"
+
+	// migrationLockTimeout is how long a replica waits for the migration of another replica, which holds the advisory lock.
+	migrationLockTimeout time.Duration = 10 * time.Minute
+
+	migration_source_error string = "could not open migration source %s: %w"
 )
 
-// executeDatabaseMigration performs database migrations based on the provided source URL.
+// MigrationStatus is the report of the migrations of a database.
+type MigrationStatus struct {
+	// Version is the current version of the database, zero when no migration was applied.
+	Version uint
+	// Dirty is true when the last migration failed and the version must be fixed with Force.
+	Dirty bool
+	// Applied are the versions of the source that are applied in the database.
+	Applied []uint
+	// Pending are the versions of the source that are not applied yet.
+	Pending []uint
+}
+
+// migrationSource is a migration source registered from a fs.FS.
+type migrationSource struct {
+	fsys fs.FS
+	dir  string
+}
+
+// migrationSources contains the registered migration sources, by upper case datasource name.
+var migrationSources = map[string]migrationSource{}
+
+// RegisterMigrations sets a fs.FS, like an embed.FS, as the migration source of the default database.
+// It must be called before Initialize.
+//
+// fsys: the file system with the migrations.
+// dir: the directory of the migrations in the file system, ex: "migrations".
+func RegisterMigrations(fsys fs.FS, dir string) {
+	migrationSources[sqlDBDefaultName] = migrationSource{fsys, dir}
+}
+
+// RegisterDatasourceMigrations sets a fs.FS, like an embed.FS, as the migration source of a named datasource.
+// It must be called before Initialize.
+//
+// name: the datasource name, ex: REPORTING for the SQL_DB_REPORTING_* env variables.
+// fsys: the file system with the migrations.
+// dir: the directory of the migrations in the file system, ex: "migrations/reporting".
+func RegisterDatasourceMigrations(name string, fsys fs.FS, dir string) {
+	migrationSources[strings.ToUpper(name)] = migrationSource{fsys, dir}
+}
+
+// migrationConfig is the migration settings of a database.
+type migrationConfig struct {
+	name         string
+	enabledEnv   string
+	enabled      bool
+	sourceUrl    string
+	fsys         fs.FS
+	databaseName string
+}
+
+// defaultMigrationConfig returns the migration settings of the default database.
 //
-// It checks if the SQL_DB_MIGRATION environment variable is set to true before proceeding.
 // It uses the MIGRATION_SOURCE_URL environment variable for migration source. If not set, it defaults to "./migrations".
-// Returns an error if there is a failure during migration execution.
-func executeDatabaseMigration(instance *sql.DB) error {
-	if !config.SQL_DB_MIGRATION {
-		logging.Info(migrationIgnoringMsg)
+func defaultMigrationConfig() migrationConfig {
+	return migrationConfig{
+		name:         sqlDBDefaultName,
+		enabledEnv:   config.ENV_SQL_DB_MIGRATION,
+		enabled:      config.SQL_DB_MIGRATION,
+		sourceUrl:    os.Getenv(migrationSourceURLEnv),
+		databaseName: config.SQL_DB_NAME,
+	}.withRegisteredSource()
+}
+
+// datasourceMigrationConfig returns the migration settings of a named datasource.
+//
+// If the datasource migration source url is not set, it defaults to "./migrations/<datasource name in lower case>".
+func datasourceMigrationConfig(datasource config.SQLDatasource) migrationConfig {
+	sourceUrl := datasource.MigrationSourceURL
+	if sourceUrl == "" {
+		sourceUrl = path.Join(migrationDefaultPath, strings.ToLower(datasource.Name))
+	}
+
+	return migrationConfig{
+		name:         datasource.Name,
+		enabledEnv:   fmt.Sprintf("SQL_DB_%s_MIGRATION", datasource.Name),
+		enabled:      datasource.Migration,
+		sourceUrl:    sourceUrl,
+		databaseName: datasource.DBName,
+	}.withRegisteredSource()
+}
+
+// withRegisteredSource returns the settings using the fs.FS registered for the database, if there is one.
+func (cfg migrationConfig) withRegisteredSource() migrationConfig {
+	if registered, ok := migrationSources[cfg.name]; ok {
+		cfg.fsys, cfg.sourceUrl = registered.fsys, registered.dir
+	}
+
+	return cfg
+}
+
+// openSource opens the registered fs.FS, or the directory of the source url.
+//
+// Returns the source driver and an error when the source cannot be opened.
+func (cfg migrationConfig) openSource() (source.Driver, error) {
+	var (
+		driver source.Driver
+		err    error
+	)
+
+	if cfg.fsys != nil {
+		driver, err = iofs.New(cfg.fsys, cfg.sourceUrl)
+	} else {
+		driver, err = source.Open("file://" + cfg.sourceUrl)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(migration_source_error, cfg.sourceUrl, err)
+	}
+
+	return driver, nil
+}
+
+// executeDatabaseMigration performs database migrations based on the provided migration settings.
+//
+// It checks if the migration is enabled before proceeding. When several replicas start together, the first one
+// applies the migrations while the others wait for the database lock and find no change.
+// Returns an error if the source cannot be opened or if there is a failure during migration execution.
+func executeDatabaseMigration(instance *sql.DB, cfg migrationConfig) error {
+	if !cfg.enabled {
+		logging.Info(migrationIgnoringMsg, cfg.name, cfg.enabledEnv)
 		return nil
 	}
 
-	sourceUrl := os.Getenv(migrationSourceURLEnv)
-	if sourceUrl == "" {
-		sourceUrl = migrationDefaultPath
+	if cfg.sourceUrl == "" {
+		cfg.sourceUrl = migrationDefaultPath
 	}
 
 	logging.Info(migrationStartingMsg)
-	driver, err := postgres.WithInstance(instance, &postgres.Config{})
-	if err != nil {
-		logging.Error(migrationCouldNotConnectDBMsg, err)
+	logging.Info(migrationExecutingInfoMsg, cfg.sourceUrl)
+	migrator := &Migrator{ctx: context.Background(), instance: instance, cfg: cfg}
+	if err := migrator.Up(); err != nil {
+		logging.Error(migrationExecutionWithErrorMsg, err)
 		return err
 	}
 
-	logging.Info(migrationExecutingInfoMsg, sourceUrl)
-	migrateDatabaseInstance, _ := migrate.NewWithDatabaseInstance("file://"+sourceUrl, config.SQL_DB_NAME, driver)
-	if migrateDatabaseInstance != nil {
-		if err = migrateDatabaseInstance.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-			logging.Error(migrationExecutionWithErrorMsg, err)
+	logging.Info(migrationFinalizedMsg)
+	return nil
+}
+
+// Migrator is a struct for managing the migrations of a database.
+type Migrator struct {
+	ctx      context.Context
+	instance *sql.DB
+	cfg      migrationConfig
+}
+
+// NewMigrator creates a new pointer to Migrator struct for the database of the context.
+// The migration source is the same used by Initialize: the registered fs.FS or the source url.
+//
+// ctx: the context.Context, bound to a named datasource with WithDatasource or to the default database.
+// Returns a pointer to Migrator struct.
+func NewMigrator(ctx context.Context) *Migrator {
+	cfg := defaultMigrationConfig()
+	if name, ok := ctx.Value(SqlDatasourceContext).(string); ok {
+		cfg = datasourceMigrationConfig(config.SQL_DB_DATASOURCES[strings.ToUpper(name)])
+	}
+
+	if cfg.sourceUrl == "" {
+		cfg.sourceUrl = migrationDefaultPath
+	}
+
+	return &Migrator{ctx: ctx, instance: getInstance(ctx), cfg: cfg}
+}
+
+// Up applies all pending migrations.
+//
+// No parameters.
+// Returns an error.
+func (m *Migrator) Up() error {
+	return m.execute(func(instance *migrate.Migrate, _ source.Driver) error {
+		return ignoreNoChange(instance.Up())
+	})
+}
+
+// Down reverts the last applied migration.
+//
+// No parameters.
+// Returns an error.
+func (m *Migrator) Down() error {
+	return m.execute(func(instance *migrate.Migrate, _ source.Driver) error {
+		return ignoreNoChange(instance.Steps(-1))
+	})
+}
+
+// Goto applies or reverts the migrations until the database is in the version.
+//
+// version: the target version.
+// Returns an error.
+func (m *Migrator) Goto(version uint) error {
+	return m.execute(func(instance *migrate.Migrate, _ source.Driver) error {
+		return ignoreNoChange(instance.Migrate(version))
+	})
+}
+
+// Force sets the version of the database and clears the dirty flag, without executing migrations.
+// It's used to recover the database after a failed migration.
+//
+// version: the version to set, or -1 for no version.
+// Returns an error.
+func (m *Migrator) Force(version int) error {
+	return m.execute(func(instance *migrate.Migrate, _ source.Driver) error {
+		return instance.Force(version)
+	})
+}
+
+// Status returns the current version of the database and the applied and pending versions of the source.
+//
+// No parameters.
+// Returns a pointer to MigrationStatus and an error.
+func (m *Migrator) Status() (*MigrationStatus, error) {
+	status := &MigrationStatus{Applied: []uint{}, Pending: []uint{}}
+	err := m.execute(func(instance *migrate.Migrate, src source.Driver) error {
+		version, dirty, err := instance.Version()
+		if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
 			return err
 		}
+		hasVersion := err == nil
+		status.Version, status.Dirty = version, dirty
+
+		for current, err := src.First(); !errors.Is(err, os.ErrNotExist); current, err = src.Next(current) {
+			if err != nil {
+				return err
+			}
+
+			if hasVersion && current <= version {
+				status.Applied = append(status.Applied, current)
+			} else {
+				status.Pending = append(status.Pending, current)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	logging.Info(migrationFinalizedMsg)
-	return nil
+	return status, nil
+}
+
+// execute opens the migration source and the migration driver of the database dialect, and executes the operation.
+// The operations hold a database lock, an advisory lock in PostgreSQL, so replicas execute them one at a time.
+//
+// fn: the operation to execute.
+// Returns an error.
+func (m *Migrator) execute(fn func(instance *migrate.Migrate, src source.Driver) error) error {
+	if m.instance == nil {
+		return errors.New(db_not_initialized_error)
+	}
+
+	src, err := m.cfg.openSource()
+	if err != nil {
+		return err
+	}
+	defer closer(src)
+
+	driver, driverCloser, err := dialectOf(m.instance).MigrationDriver(m.ctx, m.instance, m.cfg.databaseName)
+	if err != nil {
+		logging.Error(migrationCouldNotConnectDBMsg, err)
+		return err
+	}
+	if driverCloser != nil {
+		defer closer(driverCloser)
+	}
+
+	instance, err := migrate.NewWithInstance("source", src, m.cfg.databaseName, driver)
+	if err != nil {
+		return err
+	}
+	instance.LockTimeout = migrationLockTimeout
+
+	return fn(instance, src)
+}
+
+// ignoreNoChange returns nil when the error is migrate.ErrNoChange.
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"testing/fstest"
 
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/test"
@@ -26,3 +27,72 @@ func TestMigrations(t *testing.T) {
 		assert.Len(t, result, 2)
 	})
 }
+
+func TestMigrator(t *testing.T) {
+	InitializeSqlDBTest()
+	ctx := context.Background()
+	os.Setenv("MIGRATION_SOURCE_URL", fmt.Sprintf("%smigrations", test.DATABASE_ENVIRONMENT_PATH))
+	t.Cleanup(func() { delete(migrationSources, sqlDBDefaultName) })
+
+	t.Run("Should return migration status", func(t *testing.T) {
+		assert.NoError(t, NewMigrator(ctx).Goto(1))
+
+		status, err := NewMigrator(ctx).Status()
+
+		assert.NoError(t, err)
+		assert.Equal(t, &MigrationStatus{Version: 1, Applied: []uint{1}, Pending: []uint{}}, status)
+	})
+
+	t.Run("Should force migration version", func(t *testing.T) {
+		assert.NoError(t, NewMigrator(ctx).Force(1))
+
+		status, err := NewMigrator(ctx).Status()
+
+		assert.NoError(t, err)
+		assert.False(t, status.Dirty)
+		assert.Equal(t, uint(1), status.Version)
+	})
+
+	t.Run("Should return migration status from registered fs", func(t *testing.T) {
+		RegisterMigrations(os.DirFS(test.MountAbsolutPath(test.DATABASE_ENVIRONMENT_PATH)), "migrations")
+
+		status, err := NewMigrator(ctx).Status()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1}, status.Applied)
+	})
+}
+
+func TestMigrationSource(t *testing.T) {
+	t.Cleanup(func() { delete(migrationSources, sqlDBDefaultName) })
+
+	t.Run("Should return error when migration source does not exist", func(t *testing.T) {
+		_, err := migrationConfig{sourceUrl: "./not-found"}.openSource()
+
+		assert.ErrorContains(t, err, "could not open migration source ./not-found")
+	})
+
+	t.Run("Should open registered fs migration source", func(t *testing.T) {
+		RegisterMigrations(fstest.MapFS{
+			"migrations/000001_create.up.sql":   {Data: []byte("CREATE TABLE a (id INT)")},
+			"migrations/000001_create.down.sql": {Data: []byte("DROP TABLE a")},
+		}, "migrations")
+
+		cfg := defaultMigrationConfig()
+		src, err := cfg.openSource()
+
+		assert.NoError(t, err)
+		assert.NotNil(t, cfg.fsys)
+		version, err := src.First()
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), version)
+	})
+
+	t.Run("Should return error when migrating without database", func(t *testing.T) {
+		sqlDBInstance = nil
+
+		_, err := NewMigrator(context.Background()).Status()
+
+		assert.EqualError(t, err, db_not_initialized_error)
+	})
+}
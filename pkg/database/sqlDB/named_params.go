@@ -0,0 +1,186 @@
+package sqlDB
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	named_param_missing_error     string = "named parameter :%s not found in params"
+	named_param_unused_error      string = "named parameters not used in query: %s"
+	named_param_invalid_arg_error string = "named parameters must be a struct, a pointer to struct or a map[string]any, got %T"
+)
+
+// NewNamedQuery creates a new pointer to Query struct with :name placeholders bound from a struct or a map.
+//
+// ctx: the context.Context for the query
+// query: the query string with :name placeholders
+// params: a struct (fields named by db tag or snake_case) or a map[string]any with the named parameters
+// Returns a pointer to Query struct
+func NewNamedQuery[T any](ctx context.Context, query string, params any) *Query[T] {
+	positionalQuery, args, err := bindNamedParams(dialectOf(getInstance(ctx)), query, params)
+	return &Query[T]{ctx: ctx, query: positionalQuery, args: args, err: err}
+}
+
+// NewNamedStatement creates a new pointer to Statement struct with :name placeholders bound from a struct or a map.
+//
+// ctx: the context.Context for the statement
+// query: the query string with :name placeholders
+// params: a struct (fields named by db tag or snake_case) or a map[string]any with the named parameters
+// Returns a pointer to Statement struct
+func NewNamedStatement(ctx context.Context, query string, params any) *Statement {
+	positionalQuery, args, err := bindNamedParams(dialectOf(getInstance(ctx)), query, params)
+	return &Statement{ctx: ctx, query: positionalQuery, args: args, err: err}
+}
+
+// bindNamedParams rewrites the :name placeholders of the query into the positional placeholders of the dialect ($1..$n).
+//
+// dialect: the dialect of the database
+// query: the query string with :name placeholders
+// params: a struct, a pointer to struct or a map[string]any with the named parameters
+// Returns the positional query, the positional args and an error when a parameter is missing or,
+// for maps, when a parameter is not used in the query.
+func bindNamedParams(dialect Dialect, query string, params any) (string, []any, error) {
+	values, isMap, err := namedParamValues(dialect, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	names := make([]string, 0)
+	positions := make(map[string]int)
+	var positionalQuery strings.Builder
+
+	var quote rune
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case quote != 0:
+			if current == quote {
+				quote = 0
+			}
+		case current == '\'' || current == '"':
+			quote = current
+		case current == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			positionalQuery.WriteString("::")
+			i++
+			continue
+		case current == ':' && i+1 < len(runes) && isNamedParamStart(runes[i+1]):
+			end := i + 1
+			for end < len(runes) && isNamedParamPart(runes[end]) {
+				end++
+			}
+
+			name := string(runes[i+1 : end])
+			if _, exists := positions[name]; !exists {
+				if _, exists = values[name]; !exists {
+					return "", nil, fmt.Errorf(named_param_missing_error, name)
+				}
+				names = append(names, name)
+				positions[name] = len(names)
+			}
+
+			positionalQuery.WriteString(dialect.Placeholder(positions[name]))
+			i = end - 1
+			continue
+		}
+
+		positionalQuery.WriteRune(current)
+	}
+
+	if isMap && len(values) > len(names) {
+		return "", nil, fmt.Errorf(named_param_unused_error, strings.Join(unusedNamedParams(values, positions), ", "))
+	}
+
+	args := make([]any, 0, len(names))
+	for _, name := range names {
+		args = append(args, values[name])
+	}
+
+	return positionalQuery.String(), args, nil
+}
+
+// namedParamValues converts the params into a map of values by name.
+//
+// dialect: the dialect of the database, used to wrap the arrays
+// params: a struct, a pointer to struct or a map[string]any with the named parameters
+// Returns the values map, a boolean indicating if params is a map, and an error if the params type is not supported.
+func namedParamValues(dialect Dialect, params any) (map[string]any, bool, error) {
+	if values, ok := params.(map[string]any); ok {
+		return values, true, nil
+	}
+
+	value := reflect.ValueOf(params)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, false, fmt.Errorf(named_param_invalid_arg_error, params)
+	}
+
+	values := make(map[string]any)
+	structNamedParamValues(dialect, values, value)
+	return values, false, nil
+}
+
+// structNamedParamValues fills the values map with the struct fields, including the embedded struct fields.
+//
+// dialect: the dialect of the database, used to wrap the arrays
+// values: the values map to fill
+// value: the struct value
+// No return values.
+func structNamedParamValues(dialect Dialect, values map[string]any, value reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		if field.Anonymous && reflect.Indirect(fieldValue).Kind() == reflect.Struct {
+			if fieldValue.Kind() == reflect.Pointer && fieldValue.IsNil() {
+				continue
+			}
+			structNamedParamValues(dialect, values, reflect.Indirect(fieldValue))
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := columnName(field)
+		if !ok {
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() != reflect.Uint8 {
+			values[name] = dialect.ArrayArgument(fieldValue.Interface())
+		} else {
+			values[name] = fieldValue.Interface()
+		}
+	}
+}
+
+// unusedNamedParams returns the sorted names of the values not used in the query.
+func unusedNamedParams(values map[string]any, positions map[string]int) []string {
+	unused := make([]string, 0)
+	for name := range values {
+		if _, used := positions[name]; !used {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// isNamedParamStart returns true if the rune can start a named parameter.
+func isNamedParamStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isNamedParamPart returns true if the rune can be part of a named parameter.
+func isNamedParamPart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,79 @@
+package sqlDB
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type namedParamsBase struct {
+	Id int
+}
+
+type namedParamsUser struct {
+	namedParamsBase
+	Name      string
+	BirthDate string   `db:"birthday"`
+	Tags      []string `db:"tags"`
+	Ignored   string   `db:"-"`
+}
+
+func TestNamedParams(t *testing.T) {
+	t.Run("Should bind named params from struct", func(t *testing.T) {
+		user := namedParamsUser{namedParamsBase{1}, "User 1", "2000-01-01", []string{"a"}, "ignored"}
+
+		query, args, err := bindNamedParams(Postgres, "INSERT INTO users (id, name, birthday) VALUES (:id, :name, :birthday)", &user)
+		assert.NoError(t, err)
+		assert.Equal(t, "INSERT INTO users (id, name, birthday) VALUES ($1, $2, $3)", query)
+		assert.Equal(t, []any{1, "User 1", "2000-01-01"}, args)
+	})
+
+	t.Run("Should bind slice fields as arrays", func(t *testing.T) {
+		_, args, err := bindNamedParams(Postgres, "SELECT * FROM users WHERE tags && :tags", namedParamsUser{Tags: []string{"a", "b"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []any{pq.Array([]string{"a", "b"})}, args)
+	})
+
+	t.Run("Should bind named params from map reusing repeated params position", func(t *testing.T) {
+		query, args, err := bindNamedParams(Postgres, "SELECT * FROM users WHERE name = :name OR nickname = :name AND id > :id", map[string]any{"name": "User", "id": 1})
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users WHERE name = $1 OR nickname = $1 AND id > $2", query)
+		assert.Equal(t, []any{"User", 1}, args)
+	})
+
+	t.Run("Should ignore casts and quoted text", func(t *testing.T) {
+		query, args, err := bindNamedParams(Postgres, `SELECT ':text', ":column" FROM users WHERE birthday = :birthday::date`, map[string]any{"birthday": "2000-01-01"})
+		assert.NoError(t, err)
+		assert.Equal(t, `SELECT ':text', ":column" FROM users WHERE birthday = $1::date`, query)
+		assert.Equal(t, []any{"2000-01-01"}, args)
+	})
+
+	t.Run("Should return error when named param is missing", func(t *testing.T) {
+		_, _, err := bindNamedParams(Postgres, "SELECT * FROM users WHERE id = :id AND name = :name", map[string]any{"id": 1})
+		assert.EqualError(t, err, fmt.Sprintf(named_param_missing_error, "name"))
+	})
+
+	t.Run("Should return error when map param is not used", func(t *testing.T) {
+		_, _, err := bindNamedParams(Postgres, "SELECT * FROM users WHERE id = :id", map[string]any{"id": 1, "name": "User", "age": 10})
+		assert.EqualError(t, err, fmt.Sprintf(named_param_unused_error, "age, name"))
+	})
+
+	t.Run("Should return error when params type is invalid", func(t *testing.T) {
+		_, _, err := bindNamedParams(Postgres, "SELECT * FROM users WHERE id = :id", 1)
+		assert.EqualError(t, err, fmt.Sprintf(named_param_invalid_arg_error, 1))
+	})
+
+	t.Run("Should return bind error when executing named query and statement", func(t *testing.T) {
+		ctx := context.Background()
+		expected := fmt.Sprintf(named_param_missing_error, "id")
+
+		result, err := NewNamedQuery[User](ctx, "SELECT * FROM users WHERE id = :id", map[string]any{}).One()
+		assert.EqualError(t, err, expected)
+		assert.Nil(t, result)
+
+		assert.EqualError(t, NewNamedStatement(ctx, "DELETE FROM users WHERE id = :id", map[string]any{}).Execute(), expected)
+	})
+}
@@ -4,16 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
 
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
 )
 
-const (
-	pageTotalPostgresQuery string = "SELECT COUNT(tb.*) FROM (%s) tb"
-	pageDataPostgresQuery  string = "%s ORDER BY %s LIMIT %d OFFSET %d"
-)
-
 // PageQuery is a struct for sql page query
 type PageQuery[T any] struct {
 	ctx   context.Context
@@ -33,15 +27,16 @@ func NewPageQuery[T any](ctx context.Context, page *types.PageRequest, query str
 	return &PageQuery[T]{ctx, page, query, params}
 }
 
-// Execute returns a pointer of page type with slice of T data.
+// Execute returns a pointer of page type with slice of T data. The query is executed in a read replica when there is one.
 //
 // No parameters.
 // Returns a pointer to PageQuery struct and an error.
 func (q *PageQuery[T]) Execute() (*types.Page[T], error) {
-	return q.ExecuteInInstance(sqlDBInstance)
+	return q.ExecuteInInstance(getReadInstance(q.ctx))
 }
 
 // ExecuteInInstance executes the page query in the given database instance.
+// The page metadata (page, size, total pages, first and last) is filled from the page request.
 //
 // Parameters:
 // - instance: the database instance to execute the query in.
@@ -51,15 +46,17 @@ func (q *PageQuery[T]) ExecuteInInstance(instance *sql.DB) (*types.Page[T], erro
 		return nil, err
 	}
 
-	var result types.Page[T]
-	var err error
-	result.TotalElements, err = q.pageTotal(instance)
+	total, err := q.pageTotal(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := q.pageData(instance)
 	if err != nil {
 		return nil, err
 	}
 
-	result.Content, err = q.pageData(instance)
-	return &result, err
+	return types.NewPage(content, total, q.page), nil
 }
 
 // pageTotal calculates the total number of records in the query result.
@@ -67,11 +64,12 @@ func (q *PageQuery[T]) ExecuteInInstance(instance *sql.DB) (*types.Page[T], erro
 // Parameters:
 // - instance: the database instance to execute the query in.
 // Returns a uint64 representing the total number of records and an error.
-func (q *PageQuery[T]) pageTotal(instance *sql.DB) (uint64, error) {
-	query := fmt.Sprintf(pageTotalPostgresQuery, q.query)
+func (q *PageQuery[T]) pageTotal(instance *sql.DB) (result uint64, err error) {
+	query := dialectOf(instance).CountQuery(q.query)
+	ctx, finish := startQueryHooks(q.ctx, PAGE_QUERY_OPERATION, query, q.args)
+	defer func() { finish(1, err) }()
 
-	var result uint64
-	err := q.queryRowContext(instance, query).Scan(&result)
+	err = q.queryRowContext(ctx, instance, query).Scan(&result)
 	return result, err
 }
 
@@ -80,10 +78,12 @@ func (q *PageQuery[T]) pageTotal(instance *sql.DB) (uint64, error) {
 // Parameters:
 // - instance: the database instance to retrieve data from.
 // Returns a slice of type T and an error.
-func (q *PageQuery[T]) pageData(instance *sql.DB) ([]T, error) {
-	query := fmt.Sprintf(pageDataPostgresQuery, q.query, q.page.GetOrder(), q.page.Size, ((q.page.Page - 1) * q.page.Size))
+func (q *PageQuery[T]) pageData(instance *sql.DB) (content []T, err error) {
+	query := dialectOf(instance).PageQuery(q.query, q.page.GetOrder(), int(q.page.Size), int((q.page.Page-1)*q.page.Size))
+	ctx, finish := startQueryHooks(q.ctx, PAGE_QUERY_OPERATION, query, q.args)
+	defer func() { finish(int64(len(content)), err) }()
 
-	rows, err := q.queryContext(instance, query)
+	rows, err := q.queryContext(ctx, instance, query)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +92,7 @@ func (q *PageQuery[T]) pageData(instance *sql.DB) ([]T, error) {
 	return getDataList[T](rows)
 }
 
-// validate checks if the PageQuery instance is initialized, if the page is empty, and if the query is empty.
+// validate checks if the PageQuery instance is initialized, if the page is empty or has an unsafe order, and if the query is empty.
 //
 // instance: the database instance to validate against
 // Returns an error.
@@ -105,6 +105,10 @@ func (q *PageQuery[T]) validate(instance *sql.DB) error {
 		return errors.New(page_is_empty_error)
 	}
 
+	if err := q.page.Validate(); err != nil {
+		return err
+	}
+
 	if q.query == "" {
 		return errors.New(query_is_empty_error)
 	}
@@ -115,27 +119,29 @@ func (q *PageQuery[T]) validate(instance *sql.DB) error {
 // queryContext executes a query on the provided SQL instance.
 //
 // Parameters:
+// - ctx: the context of the execution, returned by the query hooks.
 // - instance: The *sql.DB instance to execute the query.
 // - query: The SQL query string to execute.
 // Returns the resulting rows and an error.
-func (q *PageQuery[T]) queryContext(instance *sql.DB, query string) (*sql.Rows, error) {
-	if tx := q.ctx.Value(SqlTxContext); tx != nil {
-		return tx.(*sql.Tx).QueryContext(q.ctx, query, q.args...)
+func (q *PageQuery[T]) queryContext(ctx context.Context, instance *sql.DB, query string) (*sql.Rows, error) {
+	if tx, ok := transactionOf(ctx, instance); ok {
+		return tx.QueryContext(ctx, query, q.args...)
 	}
 
-	return instance.QueryContext(q.ctx, query, q.args...)
+	return instance.QueryContext(ctx, query, q.args...)
 }
 
 // queryRowContext executes a query on the provided SQL instance and returns a single row.
 //
 // Parameters:
+// - ctx: the context of the execution, returned by the query hooks.
 // - instance: The *sql.DB instance to execute the query.
 // - query: The SQL query string to execute.
 // Returns the resulting row.
-func (q *PageQuery[T]) queryRowContext(instance *sql.DB, query string) *sql.Row {
-	if tx := q.ctx.Value(SqlTxContext); tx != nil {
-		return tx.(*sql.Tx).QueryRowContext(q.ctx, query, q.args...)
+func (q *PageQuery[T]) queryRowContext(ctx context.Context, instance *sql.DB, query string) *sql.Row {
+	if tx, ok := transactionOf(ctx, instance); ok {
+		return tx.QueryRowContext(ctx, query, q.args...)
 	}
 
-	return instance.QueryRowContext(q.ctx, query, q.args...)
+	return instance.QueryRowContext(ctx, query, q.args...)
 }
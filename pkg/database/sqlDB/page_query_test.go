@@ -2,6 +2,7 @@ package sqlDB
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
@@ -22,6 +23,16 @@ func TestPageQueryWithoutInitialize(t *testing.T) {
 		assert.Error(t, err, db_not_initialized_error)
 		assert.Nil(t, result)
 	})
+
+	t.Run("Should return error when execute page query with unsafe order", func(t *testing.T) {
+		instance, _ := sql.Open("postgres", "")
+		page := types.NewPageRequest(1, 1, []types.Sort{types.NewSort(types.ASC, "name; DROP TABLE users")})
+
+		result, err := NewPageQuery[User](context.Background(), page, query_base).ExecuteInInstance(instance)
+
+		assert.EqualError(t, err, "invalid sort field name; DROP TABLE users")
+		assert.Nil(t, result)
+	})
 }
 
 func TestPageQuery(t *testing.T) {
@@ -53,5 +64,10 @@ func TestPageQuery(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, "OTHER USER", result.Content[0].Name)
 		assert.Equal(t, uint64(2), result.TotalElements)
+		assert.Equal(t, uint16(1), result.Page)
+		assert.Equal(t, uint16(1), result.Size)
+		assert.Equal(t, uint64(2), result.TotalPages)
+		assert.True(t, result.First)
+		assert.False(t, result.Last)
 	})
 }
@@ -14,6 +14,7 @@ type Query[T any] struct {
 	cache *cacheDB.Cache[T]
 	query string
 	args  []any
+	err   error
 }
 
 // NewQuery create a new pointer to Query struct.
@@ -23,7 +24,7 @@ type Query[T any] struct {
 // params: variadic interface{} for additional parameters
 // Returns a pointer to Query struct
 func NewQuery[T any](ctx context.Context, query string, params ...any) *Query[T] {
-	return &Query[T]{ctx, nil, query, params}
+	return &Query[T]{ctx: ctx, query: query, args: params}
 }
 
 // NewCachedQuery create a new pointer to Query struct with cache.
@@ -34,14 +35,14 @@ func NewQuery[T any](ctx context.Context, query string, params ...any) *Query[T]
 // params: variadic interface{} for additional parameters
 // Returns a pointer to Query struct
 func NewCachedQuery[T any](ctx context.Context, cache *cacheDB.Cache[T], query string, params ...any) (q *Query[T]) {
-	return &Query[T]{ctx, cache, query, params}
+	return &Query[T]{ctx: ctx, cache: cache, query: query, args: params}
 }
 
-// Many returns a slice of T value.
+// Many returns a slice of T value. The query is executed in a read replica when there is one.
 //
 // No parameters are required. Returns a slice of T value and an error.
 func (q *Query[T]) Many() ([]T, error) {
-	return q.ManyInInstance(sqlDBInstance)
+	return q.ManyInInstance(getReadInstance(q.ctx))
 }
 
 // ManyInInstance retrieves multiple items of type T for the given SQL instance.
@@ -68,14 +69,17 @@ func (q *Query[T]) ManyInInstance(instance *sql.DB) ([]T, error) {
 //
 // instance: The *sql.DB instance to execute the query.
 // Returns a slice of retrieved items of type T and an error.
-func (q *Query[T]) fetchMany(instance *sql.DB) ([]T, error) {
-	rows, err := q.queryContext(instance)
+func (q *Query[T]) fetchMany(instance *sql.DB) (list []T, err error) {
+	ctx, finish := startQueryHooks(q.ctx, QUERY_OPERATION, q.query, q.args)
+	defer func() { finish(int64(len(list)), err) }()
+
+	rows, err := q.queryContext(ctx, instance)
 	if err != nil {
 		return nil, err
 	}
 	defer closer(rows)
 
-	list, err := getDataList[T](rows)
+	list, err = getDataList[T](rows)
 	if err != nil {
 		return nil, err
 	}
@@ -87,12 +91,12 @@ func (q *Query[T]) fetchMany(instance *sql.DB) ([]T, error) {
 	return list, nil
 }
 
-// One return a pointer of T value
+// One return a pointer of T value. The query is executed in a read replica when there is one.
 //
 // No parameters.
 // Returns a pointer of T and an error.
 func (q *Query[T]) One() (*T, error) {
-	return q.OneInInstance(sqlDBInstance)
+	return q.OneInInstance(getReadInstance(q.ctx))
 }
 
 // OneInInstance retrieves a single item of type T for the given SQL instance.
@@ -119,12 +123,25 @@ func (q *Query[T]) OneInInstance(instance *sql.DB) (*T, error) {
 //
 // instance: The *sql.DB instance to execute the query.
 // Returns a pointer of T and an error.
-func (q *Query[T]) fetchOne(instance *sql.DB) (*T, error) {
-	model := new(T)
-	if err := q.queryRowContext(instance).Scan(reflectCols(model)...); err != nil && err != sql.ErrNoRows {
+func (q *Query[T]) fetchOne(instance *sql.DB) (model *T, err error) {
+	ctx, finish := startQueryHooks(q.ctx, QUERY_OPERATION, q.query, q.args)
+	defer func() {
+		if model != nil {
+			finish(1, err)
+			return
+		}
+		finish(0, err)
+	}()
+
+	rows, err := q.queryContext(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	defer closer(rows)
+
+	model, err = getData[T](rows)
+	if err != nil || model == nil {
 		return nil, err
-	} else if err == sql.ErrNoRows {
-		return nil, nil
 	}
 
 	if q.cache != nil {
@@ -134,11 +151,89 @@ func (q *Query[T]) fetchOne(instance *sql.DB) (*T, error) {
 	return model, nil
 }
 
-// validate checks if the Query instance is initialized and if the query is empty.
+// Each executes the query and calls fn for each row, scanning one row at a time instead of loading all rows in memory.
+// The query is executed in a read replica when there is one, and the cache is not used.
+//
+// fn: the function called with each row, returning an error to stop the iteration
+// Returns the error of the query, of the context or returned by fn.
+func (q *Query[T]) Each(fn func(T) error) error {
+	return q.EachInInstance(getReadInstance(q.ctx), fn)
+}
+
+// EachInInstance executes the query in the given SQL instance and calls fn for each row.
+//
+// instance: The *sql.DB instance to execute the query.
+// fn: the function called with each row, returning an error to stop the iteration
+// Returns the error of the query, of the context or returned by fn.
+func (q *Query[T]) EachInInstance(instance *sql.DB, fn func(T) error) (err error) {
+	q.IterInInstance(instance)(func(model T, iterErr error) bool {
+		if err = iterErr; err == nil {
+			err = fn(model)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// Iter returns an iterator over the query rows, compatible with iter.Seq2[T, error], scanning one row at a time.
+// The rows are closed when the iteration ends, including early exits. An error is yielded once and ends the iteration.
+// The query is executed in a read replica when there is one, and the cache is not used.
+//
+// No parameters.
+// Returns the iterator function.
+func (q *Query[T]) Iter() func(yield func(T, error) bool) {
+	return q.IterInInstance(getReadInstance(q.ctx))
+}
+
+// IterInInstance returns an iterator over the query rows executed in the given SQL instance.
+//
+// instance: The *sql.DB instance to execute the query.
+// Returns the iterator function.
+func (q *Query[T]) IterInInstance(instance *sql.DB) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if err := q.validate(instance); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		ctx, finish := startQueryHooks(q.ctx, QUERY_OPERATION, q.query, q.args)
+		var count int64
+		rows, err := q.queryContext(ctx, instance)
+		defer func() { finish(count, err) }()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer closer(rows)
+
+		var ctxErr error
+		stopped := false
+		err = scanRows(rows, func(model *T) bool {
+			if ctxErr = q.ctx.Err(); ctxErr != nil {
+				return false
+			}
+
+			count++
+			stopped = !yield(*model, nil)
+			return !stopped
+		})
+
+		if err = errors.Join(err, ctxErr); err != nil && !stopped {
+			yield(zero, err)
+		}
+	}
+}
+
+// validate checks if the Query instance is initialized, if the query is empty and if the named params are valid.
 //
 // instance: The *sql.DB instance to execute the query.
 // Returns an error.
 func (q *Query[T]) validate(instance *sql.DB) error {
+	if q.err != nil {
+		return q.err
+	}
+
 	if instance == nil {
 		return errors.New(db_not_initialized_error)
 	}
@@ -152,24 +247,13 @@ func (q *Query[T]) validate(instance *sql.DB) error {
 
 // queryContext executes a query on the provided SQL instance.
 //
+// ctx: the context of the execution, returned by the query hooks.
 // instance: The *sql.DB instance to execute the query.
 // Returns the resulting rows and an error.
-func (q *Query[T]) queryContext(instance *sql.DB) (*sql.Rows, error) {
-	if tx := q.ctx.Value(SqlTxContext); tx != nil {
-		return tx.(*sql.Tx).QueryContext(q.ctx, q.query, q.args...)
-	}
-
-	return instance.QueryContext(q.ctx, q.query, q.args...)
-}
-
-// queryRowContext executes a query on the provided SQL instance and returns a single row.
-//
-// instance: The *sql.DB instance to execute the query.
-// Returns the resulting row.
-func (q *Query[T]) queryRowContext(instance *sql.DB) *sql.Row {
-	if tx := q.ctx.Value(SqlTxContext); tx != nil {
-		return tx.(*sql.Tx).QueryRowContext(q.ctx, q.query, q.args...)
+func (q *Query[T]) queryContext(ctx context.Context, instance *sql.DB) (*sql.Rows, error) {
+	if tx, ok := transactionOf(ctx, instance); ok {
+		return tx.QueryContext(ctx, q.query, q.args...)
 	}
 
-	return instance.QueryRowContext(q.ctx, q.query, q.args...)
+	return instance.QueryContext(ctx, q.query, q.args...)
 }
@@ -0,0 +1,215 @@
+package sqlDB
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// QUERY_OPERATION is the operation of the Query executions.
+	QUERY_OPERATION string = "query"
+	// PAGE_QUERY_OPERATION is the operation of the PageQuery executions, the count and the data queries.
+	PAGE_QUERY_OPERATION string = "page_query"
+	// CURSOR_QUERY_OPERATION is the operation of the CursorQuery executions.
+	CURSOR_QUERY_OPERATION string = "cursor_query"
+	// STATEMENT_OPERATION is the operation of the Statement, ReturningStatement and BulkInsert executions.
+	STATEMENT_OPERATION string = "statement"
+
+	redactedArgFormat string = "<redacted %T>"
+	slowQueryWarnMsg  string = "slow sql %s took %s (%d rows, error: %v): %s %v"
+
+	queryMetricsNamespace string = "colibri"
+	queryMetricsSubsystem string = "sql"
+	queryStatusSuccess    string = "success"
+	queryStatusError      string = "error"
+)
+
+// QueryEvent contains the data of a sql execution sent to the query hooks.
+// The Duration, Rows and Err fields are filled only after the execution.
+type QueryEvent struct {
+	Operation string
+	Query     string
+	// Args contains the types of the arguments, the values are redacted.
+	Args     []any
+	Duration time.Duration
+	// Rows is the number of rows read by the queries or affected by the statements.
+	Rows int64
+	Err  error
+}
+
+// QueryHook is called before and after the Query, PageQuery, CursorQuery, Statement, ReturningStatement and BulkInsert executions.
+type QueryHook interface {
+	// Before is called before the execution and returns the context of the execution, ex: with tenant tags.
+	Before(ctx context.Context, event QueryEvent) context.Context
+	// After is called after the execution, with the context returned by Before.
+	After(ctx context.Context, event QueryEvent)
+}
+
+var (
+	queryHooksMutex sync.RWMutex
+	queryHooks      []QueryHook
+)
+
+// AddQueryHook registers a hook called in all the Query, PageQuery, CursorQuery, Statement, ReturningStatement and BulkInsert executions.
+//
+// hook: the QueryHook to register.
+func AddQueryHook(hook QueryHook) {
+	queryHooksMutex.Lock()
+	defer queryHooksMutex.Unlock()
+	queryHooks = append(queryHooks, hook)
+}
+
+// activeQueryHooks returns the built-in hooks enabled by the config and the registered hooks.
+//
+// No parameters.
+// Returns a slice of QueryHook.
+func activeQueryHooks() []QueryHook {
+	hooks := make([]QueryHook, 0, 2)
+	if config.SQL_DB_SLOW_QUERY_THRESHOLD_MS > 0 {
+		hooks = append(hooks, slowQueryHook{time.Duration(config.SQL_DB_SLOW_QUERY_THRESHOLD_MS) * time.Millisecond})
+	}
+	if config.SQL_DB_QUERY_METRICS {
+		hooks = append(hooks, metricsQueryHook{})
+	}
+
+	queryHooksMutex.RLock()
+	defer queryHooksMutex.RUnlock()
+	return append(hooks, queryHooks...)
+}
+
+// startQueryHooks calls the Before of the active hooks and returns the function that calls their After.
+//
+// ctx: the context of the execution.
+// operation: the operation, ex: QUERY_OPERATION.
+// query: the sql executed.
+// args: the arguments of the sql.
+// Returns the context of the execution and the function to call with the rows and the error of the execution.
+func startQueryHooks(ctx context.Context, operation, query string, args []any) (context.Context, func(rows int64, err error)) {
+	hooks := activeQueryHooks()
+	if len(hooks) == 0 {
+		return ctx, func(int64, error) {}
+	}
+
+	event := QueryEvent{Operation: operation, Query: query, Args: redactArgs(args)}
+	for _, hook := range hooks {
+		ctx = hook.Before(ctx, event)
+	}
+
+	start := time.Now()
+	return ctx, func(rows int64, err error) {
+		event.Duration = time.Since(start)
+		event.Rows = rows
+		event.Err = err
+		for _, hook := range hooks {
+			hook.After(ctx, event)
+		}
+	}
+}
+
+// redactArgs replaces the arguments with their types, keeping the nil arguments.
+//
+// args: the arguments of the sql.
+// Returns a slice with the redacted arguments.
+func redactArgs(args []any) []any {
+	redacted := make([]any, len(args))
+	for i, arg := range args {
+		if arg != nil {
+			redacted[i] = fmt.Sprintf(redactedArgFormat, arg)
+		}
+	}
+
+	return redacted
+}
+
+// slowQueryHook logs a warning for the executions slower than the threshold.
+type slowQueryHook struct {
+	threshold time.Duration
+}
+
+// Before returns the context.
+func (slowQueryHook) Before(ctx context.Context, _ QueryEvent) context.Context {
+	return ctx
+}
+
+// After logs the execution when it's slower than the threshold.
+func (h slowQueryHook) After(_ context.Context, event QueryEvent) {
+	if event.Duration >= h.threshold {
+		logging.Warn(slowQueryWarnMsg, event.Operation, event.Duration, event.Rows, event.Err, event.Query, event.Args)
+	}
+}
+
+// metricsQueryHook observes the duration and the rows of the executions in prometheus histograms.
+type metricsQueryHook struct{}
+
+var (
+	queryDurationOpts = prometheus.HistogramOpts{
+		Namespace: queryMetricsNamespace,
+		Subsystem: queryMetricsSubsystem,
+		Name:      "query_duration_seconds",
+		Help:      "Duration of the sql executions in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}
+	queryRowsOpts = prometheus.HistogramOpts{
+		Namespace: queryMetricsNamespace,
+		Subsystem: queryMetricsSubsystem,
+		Name:      "query_rows",
+		Help:      "Rows read or affected by the sql executions.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+	}
+
+	queryMetricsOnce     sync.Once
+	queryDurationMetrics *prometheus.HistogramVec
+	queryRowsMetrics     *prometheus.HistogramVec
+)
+
+// Before returns the context.
+func (metricsQueryHook) Before(ctx context.Context, _ QueryEvent) context.Context {
+	return ctx
+}
+
+// After observes the execution duration by operation and status, and the rows by operation.
+func (metricsQueryHook) After(_ context.Context, event QueryEvent) {
+	queryMetricsOnce.Do(registerQueryMetrics)
+
+	status := queryStatusSuccess
+	if event.Err != nil {
+		status = queryStatusError
+	}
+
+	queryDurationMetrics.WithLabelValues(event.Operation, status).Observe(event.Duration.Seconds())
+	queryRowsMetrics.WithLabelValues(event.Operation).Observe(float64(event.Rows))
+}
+
+// registerQueryMetrics registers the query histograms in the prometheus default registerer.
+//
+// No parameters.
+// No return values.
+func registerQueryMetrics() {
+	queryDurationMetrics = registerHistogram(queryDurationOpts, "operation", "status")
+	queryRowsMetrics = registerHistogram(queryRowsOpts, "operation")
+}
+
+// registerHistogram registers a histogram, or returns the one already registered with the same options.
+//
+// opts: the histogram options.
+// labels: the histogram label names.
+// Returns a pointer to prometheus.HistogramVec.
+func registerHistogram(opts prometheus.HistogramOpts, labels ...string) *prometheus.HistogramVec {
+	histogram := prometheus.NewHistogramVec(opts, labels)
+	if err := prometheus.Register(histogram); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			return registered.ExistingCollector.(*prometheus.HistogramVec)
+		}
+		logging.Error("could not register sql metrics %s: %v", opts.Name, err)
+	}
+
+	return histogram
+}
@@ -0,0 +1,82 @@
+package sqlDB
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type queryHookContextKey string
+
+type recordQueryHook struct {
+	before []QueryEvent
+	after  []QueryEvent
+}
+
+func (h *recordQueryHook) Before(ctx context.Context, event QueryEvent) context.Context {
+	h.before = append(h.before, event)
+	return context.WithValue(ctx, queryHookContextKey("tenant"), "tenant-1")
+}
+
+func (h *recordQueryHook) After(ctx context.Context, event QueryEvent) {
+	event.Query = event.Query + " /* " + ctx.Value(queryHookContextKey("tenant")).(string) + " */"
+	h.after = append(h.after, event)
+}
+
+func TestQueryHooks(t *testing.T) {
+	t.Cleanup(func() {
+		queryHooks = nil
+		config.SQL_DB_SLOW_QUERY_THRESHOLD_MS = 0
+		config.SQL_DB_QUERY_METRICS = false
+	})
+
+	t.Run("Should redact the arguments", func(t *testing.T) {
+		assert.Equal(t, []any{"<redacted string>", nil, "<redacted int>"}, redactArgs([]any{"secret", nil, 10}))
+	})
+
+	t.Run("Should return only the hooks enabled by config", func(t *testing.T) {
+		queryHooks = nil
+		assert.Empty(t, activeQueryHooks())
+
+		config.SQL_DB_SLOW_QUERY_THRESHOLD_MS = 100
+		config.SQL_DB_QUERY_METRICS = true
+		hook := &recordQueryHook{}
+		AddQueryHook(hook)
+
+		assert.Equal(t, []QueryHook{slowQueryHook{100 * time.Millisecond}, metricsQueryHook{}, hook}, activeQueryHooks())
+	})
+
+	t.Run("Should call the hooks before and after the execution", func(t *testing.T) {
+		queryHooks = nil
+		config.SQL_DB_SLOW_QUERY_THRESHOLD_MS = 0
+		config.SQL_DB_QUERY_METRICS = false
+		hook := &recordQueryHook{}
+		AddQueryHook(hook)
+
+		ctx, finish := startQueryHooks(context.Background(), QUERY_OPERATION, "SELECT * FROM users WHERE name = $1", []any{"secret"})
+		finish(3, errors.New("query error"))
+
+		assert.Equal(t, "tenant-1", ctx.Value(queryHookContextKey("tenant")))
+		assert.Equal(t, []QueryEvent{{Operation: QUERY_OPERATION, Query: "SELECT * FROM users WHERE name = $1", Args: []any{"<redacted string>"}}}, hook.before)
+		assert.Len(t, hook.after, 1)
+		assert.Equal(t, "SELECT * FROM users WHERE name = $1 /* tenant-1 */", hook.after[0].Query)
+		assert.Equal(t, int64(3), hook.after[0].Rows)
+		assert.EqualError(t, hook.after[0].Err, "query error")
+		assert.Positive(t, hook.after[0].Duration)
+	})
+
+	t.Run("Should observe the executions in the metrics", func(t *testing.T) {
+		hook := metricsQueryHook{}
+		hook.After(context.Background(), QueryEvent{Operation: STATEMENT_OPERATION, Duration: time.Millisecond, Rows: 2})
+		hook.After(context.Background(), QueryEvent{Operation: STATEMENT_OPERATION, Err: errors.New("statement error")})
+
+		assert.Equal(t, 2, testutil.CollectAndCount(queryDurationMetrics))
+		assert.Equal(t, 1, testutil.CollectAndCount(queryRowsMetrics))
+		assert.Equal(t, queryDurationMetrics, registerHistogram(queryDurationOpts, "operation", "status"))
+	})
+}
@@ -2,6 +2,7 @@ package sqlDB
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -42,6 +43,19 @@ func TestQueryWithoutInitialize(t *testing.T) {
 		assert.Error(t, err, db_not_initialized_error)
 		assert.Nil(t, result)
 	})
+
+	t.Run("Should return error when iterate query with db not initialized error", func(t *testing.T) {
+		err := NewQuery[User](ctx, query_base).Each(func(User) error { return nil })
+		assert.EqualError(t, err, db_not_initialized_error)
+
+		errs := make([]error, 0)
+		NewQuery[User](ctx, query_base).Iter()(func(_ User, err error) bool {
+			errs = append(errs, err)
+			return true
+		})
+		assert.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], db_not_initialized_error)
+	})
 }
 
 func TestQuery(t *testing.T) {
@@ -96,6 +110,59 @@ func TestQuery(t *testing.T) {
 		assert.Len(t, result, 1)
 		assert.Equal(t, "ADMIN USER", result[0].Name)
 	})
+
+	t.Run("Should iterate over all rows with each", func(t *testing.T) {
+		names := make([]string, 0)
+		err := NewQuery[User](ctx, query_base+" ORDER BY u.id").Each(func(user User) error {
+			names = append(names, user.Name)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ADMIN USER", "OTHER USER"}, names)
+	})
+
+	t.Run("Should stop each and return the function error", func(t *testing.T) {
+		calls := 0
+		err := NewQuery[User](ctx, query_base).Each(func(User) error {
+			calls++
+			return errors.New("stop")
+		})
+
+		assert.EqualError(t, err, "stop")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Should stop iterator on early exit", func(t *testing.T) {
+		users := make([]User, 0)
+		NewQuery[User](ctx, query_base+" ORDER BY u.id").Iter()(func(user User, err error) bool {
+			assert.NoError(t, err)
+			users = append(users, user)
+			return false
+		})
+
+		assert.Len(t, users, 1)
+		assert.Equal(t, "ADMIN USER", users[0].Name)
+	})
+
+	t.Run("Should return error when iterate with context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := NewQuery[User](ctx, query_base).Each(func(User) error { return nil })
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Should map columns by name when the columns order differs from the fields order", func(t *testing.T) {
+		result, err := NewQuery[User](ctx, "SELECT p.name AS profile_name, u.name, p.id AS profile_id, u.id, u.birthday FROM users u JOIN profiles p ON u.profile_id = p.id WHERE u.id = $1", 1).One()
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, 1, result.Id)
+		assert.Equal(t, "ADMIN USER", result.Name)
+		assert.Equal(t, Profile{Id: 100, Name: "ADMIN"}, result.Profile)
+	})
 }
 
 func TestQueryWithoutCacheDBInitialize(t *testing.T) {
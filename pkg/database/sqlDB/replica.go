@@ -0,0 +1,190 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/monitoring"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/observer"
+)
+
+// SqlPrimaryContextKey is the type of the context key to force the use of the primary database.
+type SqlPrimaryContextKey string
+
+const (
+	SqlPrimaryContext SqlPrimaryContextKey = "SqlPrimaryContext"
+
+	replicaNameFormat         string        = "%s-REPLICA-%d"
+	replicaHealthCheckTimeout time.Duration = 5 * time.Second
+
+	replica_unhealthy_warn string = "%s read replica is unhealthy and was ejected: %v"
+	replica_healthy_info   string = "%s read replica is healthy"
+)
+
+// replica is a read replica database instance with its health state.
+type replica struct {
+	name     string
+	instance *sql.DB
+	healthy  atomic.Bool
+}
+
+// replicaSet is the group of read replicas of a primary database instance.
+type replicaSet struct {
+	strategy string
+	replicas []*replica
+	counter  atomic.Uint64
+	done     chan struct{}
+}
+
+// replicaSets contains the read replicas by primary database instance.
+var replicaSets = map[*sql.DB]*replicaSet{}
+
+// initializeReplicas opens the read replicas connections of a primary database instance and starts their health check.
+//
+// name: the primary database name.
+// primary: the primary database instance.
+// uris: the read replicas connection uris.
+// maxOpenConns: the maximum number of open connections of each replica, the same of the primary.
+// maxIdleConns: the maximum number of idle connections of each replica, the same of the primary.
+// No return values.
+func initializeReplicas(name string, primary *sql.DB, uris []string, maxOpenConns, maxIdleConns int) {
+	if len(uris) == 0 {
+		return
+	}
+
+	set := &replicaSet{strategy: config.SQL_DB_REPLICA_STRATEGY, done: make(chan struct{})}
+	for i, uri := range uris {
+		r := &replica{name: fmt.Sprintf(replicaNameFormat, name, i+1)}
+
+		var err error
+		if r.instance, err = sql.Open(monitoring.GetSQLDBDriverName(), uri); err != nil {
+			logging.Fatal(db_connection_error, r.name, err)
+		}
+		r.instance.SetMaxOpenConns(maxOpenConns)
+		r.instance.SetMaxIdleConns(maxIdleConns)
+
+		if err = r.check(); err != nil {
+			logging.Warn(replica_unhealthy_warn, r.name, err)
+		}
+		set.replicas = append(set.replicas, r)
+	}
+
+	go set.healthCheck(time.Duration(config.SQL_DB_REPLICA_HEALTH_CHECK_SECONDS) * time.Second)
+	observer.Attach(set)
+	replicaSets[primary] = set
+}
+
+// WithPrimary returns a copy of ctx that forces the queries to use the primary database instead of the read replicas.
+// It's used for read-your-writes cases.
+//
+// ctx: the parent context.
+// Returns a context.Context.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SqlPrimaryContext, true)
+}
+
+// getReadInstance returns a read replica of the instance bound to the context. It returns the primary instance when
+// the context contains a transaction of the primary, forces the primary, or when there is no healthy replica.
+//
+// ctx: the context of the query.
+// Returns a pointer to sql.DB.
+func getReadInstance(ctx context.Context) *sql.DB {
+	primary := getInstance(ctx)
+	if _, inTx := transactionOf(ctx, primary); inTx || ctx.Value(SqlPrimaryContext) != nil {
+		return primary
+	}
+
+	if set, exists := replicaSets[primary]; exists {
+		if instance := set.pick(); instance != nil {
+			return instance
+		}
+	}
+
+	return primary
+}
+
+// pick selects a healthy replica instance according to the strategy, or nil if there is no healthy replica.
+//
+// No parameters.
+// Returns a pointer to sql.DB.
+func (s *replicaSet) pick() *sql.DB {
+	healthy := make([]*replica, 0, len(s.replicas))
+	for _, r := range s.replicas {
+		if r.healthy.Load() {
+			healthy = append(healthy, r)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	if s.strategy == config.SQL_DB_REPLICA_LEAST_CONNECTIONS {
+		selected := healthy[0]
+		for _, r := range healthy[1:] {
+			if r.instance.Stats().InUse < selected.instance.Stats().InUse {
+				selected = r
+			}
+		}
+		return selected.instance
+	}
+
+	return healthy[(s.counter.Add(1)-1)%uint64(len(healthy))].instance
+}
+
+// healthCheck checks the replicas health periodically until the replica set is closed.
+//
+// interval: the time between the health checks.
+// No return values.
+func (s *replicaSet) healthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			for _, r := range s.replicas {
+				_ = r.check()
+			}
+		}
+	}
+}
+
+// Close stops the health check and finalizes the read replicas connections.
+//
+// No parameters.
+// No return values.
+func (s *replicaSet) Close() {
+	close(s.done)
+	for _, r := range s.replicas {
+		sqlDBObserver{r.name, r.instance}.Close()
+	}
+}
+
+// check pings the replica and updates its health state, ejecting it from the queries when it is unhealthy.
+//
+// No parameters.
+// Returns the ping error.
+func (r *replica) check() error {
+	ctx, cancel := context.WithTimeout(context.Background(), replicaHealthCheckTimeout)
+	defer cancel()
+
+	err := r.instance.PingContext(ctx)
+	healthy := err == nil
+	if r.healthy.Swap(healthy) != healthy {
+		if healthy {
+			logging.Info(replica_healthy_info, r.name)
+		} else {
+			logging.Warn(replica_unhealthy_warn, r.name, err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,94 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplica(t *testing.T) {
+	ctx := context.Background()
+	primary, _ := sql.Open("postgres", "host=primary sslmode=disable")
+	replica1, _ := sql.Open("postgres", "host=replica-1 sslmode=disable")
+	replica2, _ := sql.Open("postgres", "host=replica-2 sslmode=disable")
+
+	newReplicaSet := func(strategy string, healthy ...bool) *replicaSet {
+		set := &replicaSet{strategy: strategy}
+		for i, instance := range []*sql.DB{replica1, replica2} {
+			r := &replica{name: "replica", instance: instance}
+			r.healthy.Store(healthy[i])
+			set.replicas = append(set.replicas, r)
+		}
+		return set
+	}
+
+	t.Run("Should pick replicas with round-robin strategy", func(t *testing.T) {
+		set := newReplicaSet(config.SQL_DB_REPLICA_ROUND_ROBIN, true, true)
+
+		assert.Equal(t, replica1, set.pick())
+		assert.Equal(t, replica2, set.pick())
+		assert.Equal(t, replica1, set.pick())
+	})
+
+	t.Run("Should pick replica with least-connections strategy", func(t *testing.T) {
+		set := newReplicaSet(config.SQL_DB_REPLICA_LEAST_CONNECTIONS, true, true)
+
+		assert.Equal(t, replica1, set.pick())
+		assert.Equal(t, replica1, set.pick())
+	})
+
+	t.Run("Should not pick unhealthy replicas", func(t *testing.T) {
+		set := newReplicaSet(config.SQL_DB_REPLICA_ROUND_ROBIN, false, true)
+
+		assert.Equal(t, replica2, set.pick())
+		assert.Equal(t, replica2, set.pick())
+		assert.Nil(t, newReplicaSet(config.SQL_DB_REPLICA_ROUND_ROBIN, false, false).pick())
+	})
+
+	t.Run("Should eject replica when health check fails", func(t *testing.T) {
+		r := &replica{name: "replica", instance: replica1}
+		r.healthy.Store(true)
+
+		assert.Error(t, r.check())
+		assert.False(t, r.healthy.Load())
+	})
+
+	t.Run("Should limit replica connections with the primary pool sizes", func(t *testing.T) {
+		test.InitializeBaseTest()
+		initializeReplicas("REPORTING", primary, []string{"host=replica-1 sslmode=disable connect_timeout=1"}, 4, 2)
+		set := replicaSets[primary]
+		defer func() {
+			close(set.done)
+			delete(replicaSets, primary)
+		}()
+
+		assert.Len(t, set.replicas, 1)
+		assert.Equal(t, 4, set.replicas[0].instance.Stats().MaxOpenConnections)
+	})
+
+	t.Run("Should route reads to replicas", func(t *testing.T) {
+		datasources["REPORTING"] = primary
+		replicaSets[primary] = newReplicaSet(config.SQL_DB_REPLICA_ROUND_ROBIN, true, false)
+		defer delete(datasources, "REPORTING")
+		defer delete(replicaSets, primary)
+		ctx := WithDatasource(ctx, "REPORTING")
+
+		assert.Equal(t, replica1, getReadInstance(ctx))
+		assert.Equal(t, primary, getReadInstance(WithPrimary(ctx)))
+		assert.Equal(t, primary, getReadInstance(withTransaction(ctx, primary, &sql.Tx{})))
+		assert.Equal(t, primary, getInstance(ctx))
+	})
+
+	t.Run("Should route reads to primary when there is no healthy replica", func(t *testing.T) {
+		datasources["REPORTING"] = primary
+		replicaSets[primary] = newReplicaSet(config.SQL_DB_REPLICA_ROUND_ROBIN, false, false)
+		defer delete(datasources, "REPORTING")
+		defer delete(replicaSets, primary)
+
+		assert.Equal(t, primary, getReadInstance(WithDatasource(ctx, "REPORTING")))
+	})
+}
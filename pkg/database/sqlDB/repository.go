@@ -0,0 +1,305 @@
+package sqlDB
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
+)
+
+const (
+	db_tag_option_pk   string = "pk"
+	db_tag_option_auto string = "auto"
+
+	repositorySelectQuery    string = "SELECT %s FROM %s"
+	repositoryFindByIDQuery  string = "%s WHERE %s = %s"
+	repositoryInsertQuery    string = "INSERT INTO %s (%s) VALUES (%s)"
+	repositoryReturningQuery string = "%s RETURNING %s"
+	repositoryUpdateQuery    string = "UPDATE %s SET %s WHERE %s = %s"
+	repositoryDeleteQuery    string = "DELETE FROM %s WHERE %s = %s"
+
+	repository_invalid_model_error string = "repository model %s must be a struct"
+	repository_pk_error            string = "repository model %s must have exactly one field tagged with db:\"<column>,pk\""
+	repository_model_is_nil_error  string = "repository model is nil"
+	repository_no_update_error     string = "repository model %s has no columns to update besides the primary key and the auto columns"
+)
+
+// TableNamer is implemented by the repository models that define their table name.
+// The default table name is the snake_case name of the model type.
+type TableNamer interface {
+	TableName() string
+}
+
+// repositoryColumn is the metadata of a column mapped by a repository.
+type repositoryColumn struct {
+	name    string
+	index   []int
+	pk      bool
+	auto    bool
+	isArray bool
+}
+
+// Repository is a struct for the CRUD operations of a model mapped to a table.
+//
+// The columns are derived from the model fields by db tag or snake_case field name, including the embedded struct fields.
+// The primary key is tagged with `db:"<column>,pk"`, and the columns generated by the database (like SERIAL ids)
+// are tagged with the `auto` option to be omitted on insert and returned into the model.
+type Repository[T any, ID any] struct {
+	table   string
+	columns []repositoryColumn
+	pk      repositoryColumn
+	err     error
+}
+
+// NewRepository creates a new pointer to Repository struct.
+//
+// No parameters.
+// Returns a pointer to Repository struct
+func NewRepository[T any, ID any]() *Repository[T, ID] {
+	modelType := reflect.TypeOf((*T)(nil)).Elem()
+	r := &Repository[T, ID]{table: tableName(modelType)}
+	if modelType.Kind() != reflect.Struct {
+		r.err = fmt.Errorf(repository_invalid_model_error, modelType)
+		return r
+	}
+
+	r.columns = appendRepositoryColumns(nil, modelType, nil)
+	pks := 0
+	for _, column := range r.columns {
+		if column.pk {
+			r.pk = column
+			pks++
+		}
+	}
+
+	if pks != 1 {
+		r.err = fmt.Errorf(repository_pk_error, modelType)
+	}
+
+	return r
+}
+
+// Insert inserts the model into the table. The columns tagged with auto are returned into the model.
+//
+// ctx: the context.Context for the statement
+// model: the pointer to the model to insert
+// Returns an error.
+func (r *Repository[T, ID]) Insert(ctx context.Context, model *T) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if model == nil {
+		return errors.New(repository_model_is_nil_error)
+	}
+
+	dialect := dialectOf(getInstance(ctx))
+	value := reflect.ValueOf(model).Elem()
+	names, placeholders, args := make([]string, 0), make([]string, 0), make([]any, 0)
+	for _, column := range r.columns {
+		if column.auto {
+			continue
+		}
+
+		names = append(names, column.name)
+		args = append(args, argumentValue(dialect, fieldByIndex(value, column.index), column.isArray))
+		placeholders = append(placeholders, dialect.Placeholder(len(args)))
+	}
+
+	query := fmt.Sprintf(repositoryInsertQuery, r.table, strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	auto := r.autoColumns()
+	if len(auto) == 0 {
+		return NewStatement(ctx, query, args...).Execute()
+	}
+
+	returning := make([]string, 0, len(auto))
+	for _, column := range auto {
+		returning = append(returning, column.name)
+	}
+
+	result, err := NewReturningStatement[T](ctx, fmt.Sprintf(repositoryReturningQuery, query, strings.Join(returning, ", ")), args...).One()
+	if err != nil || result == nil {
+		return err
+	}
+
+	resultValue := reflect.ValueOf(result).Elem()
+	for _, column := range auto {
+		fieldByIndex(value, column.index).Set(fieldByIndex(resultValue, column.index))
+	}
+
+	return nil
+}
+
+// Update updates all columns of the model, except the primary key and the auto columns, by its primary key.
+//
+// ctx: the context.Context for the statement
+// model: the pointer to the model to update
+// Returns an error wrapping ErrUnexpectedRowsAffected when there is no row with the primary key,
+// or an error when the model has no columns to update.
+func (r *Repository[T, ID]) Update(ctx context.Context, model *T) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if model == nil {
+		return errors.New(repository_model_is_nil_error)
+	}
+
+	dialect := dialectOf(getInstance(ctx))
+	value := reflect.ValueOf(model).Elem()
+	sets, args := make([]string, 0), make([]any, 0)
+	for _, column := range r.columns {
+		if column.pk || column.auto {
+			continue
+		}
+
+		args = append(args, argumentValue(dialect, fieldByIndex(value, column.index), column.isArray))
+		sets = append(sets, fmt.Sprintf("%s = %s", column.name, dialect.Placeholder(len(args))))
+	}
+
+	if len(sets) == 0 {
+		return fmt.Errorf(repository_no_update_error, value.Type())
+	}
+	args = append(args, fieldByIndex(value, r.pk.index).Interface())
+
+	query := fmt.Sprintf(repositoryUpdateQuery, r.table, strings.Join(sets, ", "), r.pk.name, dialect.Placeholder(len(args)))
+	return NewStatement(ctx, query, args...).ExecuteExpecting(1)
+}
+
+// Delete deletes the row with the primary key.
+//
+// ctx: the context.Context for the statement
+// id: the primary key of the row to delete
+// Returns an error.
+func (r *Repository[T, ID]) Delete(ctx context.Context, id ID) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	placeholder := dialectOf(getInstance(ctx)).Placeholder(1)
+	return NewStatement(ctx, fmt.Sprintf(repositoryDeleteQuery, r.table, r.pk.name, placeholder), id).Execute()
+}
+
+// FindByID returns the model with the primary key, or nil if it is not found.
+//
+// ctx: the context.Context for the query
+// id: the primary key of the row to find
+// Returns a pointer of T and an error.
+func (r *Repository[T, ID]) FindByID(ctx context.Context, id ID) (*T, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	placeholder := dialectOf(getInstance(ctx)).Placeholder(1)
+	return NewQuery[T](ctx, fmt.Sprintf(repositoryFindByIDQuery, r.selectQuery(), r.pk.name, placeholder), id).One()
+}
+
+// FindAll returns a page of models. The page is sorted by the primary key when it has no order.
+//
+// ctx: the context.Context for the query
+// page: the types.PageRequest for the query
+// Returns a pointer of page type with slice of T data and an error.
+func (r *Repository[T, ID]) FindAll(ctx context.Context, page *types.PageRequest) (*types.Page[T], error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if page != nil && len(page.Order) == 0 {
+		page = types.NewPageRequest(page.Page, page.Size, []types.Sort{types.NewSort(types.ASC, r.pk.name)})
+	}
+
+	return NewPageQuery[T](ctx, page, r.selectQuery()).Execute()
+}
+
+// selectQuery returns the query that selects all columns of the table.
+func (r *Repository[T, ID]) selectQuery() string {
+	names := make([]string, 0, len(r.columns))
+	for _, column := range r.columns {
+		names = append(names, column.name)
+	}
+
+	return fmt.Sprintf(repositorySelectQuery, strings.Join(names, ", "), r.table)
+}
+
+// autoColumns returns the columns generated by the database.
+func (r *Repository[T, ID]) autoColumns() []repositoryColumn {
+	auto := make([]repositoryColumn, 0)
+	for _, column := range r.columns {
+		if column.auto {
+			auto = append(auto, column)
+		}
+	}
+
+	return auto
+}
+
+// appendRepositoryColumns appends the columns of the struct type fields, including the embedded struct fields.
+// The nested struct fields that are not column types are ignored.
+//
+// columns: the columns to append to
+// structType: the struct type
+// index: the index path of the struct from the model
+// Returns the slice of repositoryColumn.
+func appendRepositoryColumns(columns []repositoryColumn, structType reflect.Type, index []int) []repositoryColumn {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldIndex := append(append(make([]int, 0, len(index)+1), index...), i)
+
+		if field.Anonymous && !isColumnType(field.Type) {
+			if field.Type.Kind() != reflect.Pointer || field.IsExported() {
+				columns = appendRepositoryColumns(columns, indirectType(field.Type), fieldIndex)
+			}
+			continue
+		}
+
+		name, ok := columnName(field)
+		if !field.IsExported() || !ok || !isColumnType(field.Type) {
+			continue
+		}
+
+		options := columnOptions(field)
+		columns = append(columns, repositoryColumn{
+			name:    name,
+			index:   fieldIndex,
+			pk:      options[db_tag_option_pk],
+			auto:    options[db_tag_option_auto],
+			isArray: isArrayType(field.Type),
+		})
+	}
+
+	return columns
+}
+
+// columnOptions returns the options of the db tag of a struct field, ex: pk and auto in `db:"id,pk,auto"`.
+func columnOptions(field reflect.StructField) map[string]bool {
+	options := map[string]bool{}
+	_, tagOptions, _ := strings.Cut(field.Tag.Get(db_tag), ",")
+	for _, option := range strings.Split(tagOptions, ",") {
+		if option = strings.TrimSpace(option); option != "" {
+			options[option] = true
+		}
+	}
+
+	return options
+}
+
+// tableName returns the table name of the model type from the TableNamer interface, or the snake_case type name.
+func tableName(modelType reflect.Type) string {
+	if namer, ok := reflect.New(modelType).Interface().(TableNamer); ok {
+		return namer.TableName()
+	}
+
+	return toSnakeCase(modelType.Name())
+}
+
+// argumentValue returns the value of a field to be used as statement argument, wrapping arrays for the dialect.
+func argumentValue(dialect Dialect, value reflect.Value, isArray bool) any {
+	if isArray {
+		return dialect.ArrayArgument(value.Interface())
+	}
+
+	return value.Interface()
+}
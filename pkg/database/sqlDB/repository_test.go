@@ -0,0 +1,188 @@
+package sqlDB
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type userEntity struct {
+	ID        int `db:"id,pk"`
+	Name      string
+	Birthday  time.Time
+	ProfileID int
+	Profile   Profile
+}
+
+func (userEntity) TableName() string {
+	return "users"
+}
+
+type contactAudit struct {
+	ID int `db:"id,pk,auto"`
+}
+
+type contactEntity struct {
+	contactAudit
+	Name  string
+	Email string
+}
+
+func (*contactEntity) TableName() string {
+	return "contacts"
+}
+
+type withoutPkEntity struct {
+	Name string
+}
+
+type onlyPkEntity struct {
+	ID      int       `db:"id,pk"`
+	Created time.Time `db:"created_at,auto"`
+}
+
+func TestRepositoryMetadata(t *testing.T) {
+	t.Run("Should derive table and columns from the model", func(t *testing.T) {
+		repository := NewRepository[userEntity, int]()
+
+		assert.NoError(t, repository.err)
+		assert.Equal(t, "users", repository.table)
+		assert.Equal(t, "id", repository.pk.name)
+		assert.Equal(t, "SELECT id, name, birthday, profile_id FROM users", repository.selectQuery())
+		assert.Empty(t, repository.autoColumns())
+	})
+
+	t.Run("Should derive pointer receiver table name and embedded auto columns", func(t *testing.T) {
+		repository := NewRepository[contactEntity, int]()
+
+		assert.NoError(t, repository.err)
+		assert.Equal(t, "contacts", repository.table)
+		assert.Equal(t, []repositoryColumn{{name: "id", index: []int{0, 0}, pk: true, auto: true}}, repository.autoColumns())
+	})
+
+	t.Run("Should use snake case type name as default table name", func(t *testing.T) {
+		assert.Equal(t, "without_pk_entity", tableName(reflect.TypeOf(withoutPkEntity{})))
+	})
+
+	t.Run("Should return error when model has no primary key", func(t *testing.T) {
+		ctx := context.Background()
+		repository := NewRepository[withoutPkEntity, int]()
+		expected := fmt.Sprintf(repository_pk_error, reflect.TypeOf(withoutPkEntity{}))
+
+		assert.EqualError(t, repository.Insert(ctx, &withoutPkEntity{}), expected)
+		assert.EqualError(t, repository.Update(ctx, &withoutPkEntity{}), expected)
+		assert.EqualError(t, repository.Delete(ctx, 1), expected)
+
+		result, err := repository.FindByID(ctx, 1)
+		assert.EqualError(t, err, expected)
+		assert.Nil(t, result)
+
+		page, err := repository.FindAll(ctx, types.NewPageRequest(1, 10, nil))
+		assert.EqualError(t, err, expected)
+		assert.Nil(t, page)
+	})
+
+	t.Run("Should return error when model is nil", func(t *testing.T) {
+		repository := NewRepository[userEntity, int]()
+
+		assert.EqualError(t, repository.Insert(context.Background(), nil), repository_model_is_nil_error)
+		assert.EqualError(t, repository.Update(context.Background(), nil), repository_model_is_nil_error)
+	})
+
+	t.Run("Should return error when model has no columns to update", func(t *testing.T) {
+		repository := NewRepository[onlyPkEntity, int]()
+
+		err := repository.Update(context.Background(), &onlyPkEntity{ID: 1})
+		assert.EqualError(t, err, fmt.Sprintf(repository_no_update_error, reflect.TypeOf(onlyPkEntity{})))
+	})
+
+	t.Run("Should return error when model is not a struct", func(t *testing.T) {
+		repository := NewRepository[string, int]()
+		assert.EqualError(t, repository.err, fmt.Sprintf(repository_invalid_model_error, "string"))
+	})
+}
+
+func TestRepository(t *testing.T) {
+	InitializeSqlDBTest()
+	ctx := context.Background()
+	users := NewRepository[userEntity, int]()
+	contacts := NewRepository[contactEntity, int]()
+
+	t.Run("Should find by id", func(t *testing.T) {
+		result, err := users.FindByID(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, "ADMIN USER", result.Name)
+		assert.Equal(t, 100, result.ProfileID)
+	})
+
+	t.Run("Should return nil when id is not found", func(t *testing.T) {
+		result, err := users.FindByID(ctx, 999)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should find all sorted by primary key when page has no order", func(t *testing.T) {
+		result, err := users.FindAll(ctx, types.NewPageRequest(1, 1, nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), result.TotalElements)
+		assert.Len(t, result.Content, 1)
+		assert.Equal(t, 1, result.Content[0].ID)
+	})
+
+	t.Run("Should insert, update and delete", func(t *testing.T) {
+		user := userEntity{ID: 3, Name: "NEW USER", Birthday: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), ProfileID: 200}
+		assert.NoError(t, users.Insert(ctx, &user))
+
+		user.Name = "UPDATED USER"
+		assert.NoError(t, users.Update(ctx, &user))
+
+		result, err := users.FindByID(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "UPDATED USER", result.Name)
+
+		assert.NoError(t, users.Delete(ctx, 3))
+		result, err = users.FindByID(ctx, 3)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should return error when updating a model not found", func(t *testing.T) {
+		err := users.Update(ctx, &userEntity{ID: 999, Name: "NOT FOUND", ProfileID: 100})
+
+		assert.ErrorIs(t, err, ErrUnexpectedRowsAffected)
+	})
+
+	t.Run("Should insert returning auto columns into the model", func(t *testing.T) {
+		contact := contactEntity{Name: "Contact", Email: "repository@email.com"}
+
+		assert.NoError(t, contacts.Insert(ctx, &contact))
+		assert.NotZero(t, contact.ID)
+
+		result, err := contacts.FindByID(ctx, contact.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, &contact, result)
+	})
+
+	t.Run("Should rollback repository operations inside a transaction", func(t *testing.T) {
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if err := contacts.Insert(ctx, &contactEntity{Name: "Contact", Email: "repository-tx@email.com"}); err != nil {
+				return err
+			}
+			return fmt.Errorf("rollback")
+		})
+		assert.EqualError(t, err, "rollback")
+
+		result, err := NewQuery[contactEntity](ctx, "SELECT id, name, email FROM contacts WHERE email = $1", "repository-tx@email.com").One()
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,118 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ReturningStatement is a struct for sql statement with RETURNING clause
+type ReturningStatement[T any] struct {
+	ctx   context.Context
+	query string
+	args  []any
+}
+
+// NewReturningStatement creates a new pointer to ReturningStatement struct.
+// The RETURNING columns are mapped into T by db tag or snake_case field name.
+//
+// ctx: the context.Context for the statement
+// query: the statement with RETURNING clause, ex: INSERT INTO contacts (name) VALUES ($1) RETURNING id
+// params: variadic interface{} for additional parameters
+// Returns a pointer to ReturningStatement struct
+func NewReturningStatement[T any](ctx context.Context, query string, params ...any) *ReturningStatement[T] {
+	return &ReturningStatement[T]{ctx, query, params}
+}
+
+// One applies the statement in the database and returns the first returned row.
+//
+// No parameters.
+// Returns a pointer of T, or nil when no row is returned, and an error.
+func (s *ReturningStatement[T]) One() (*T, error) {
+	return s.OneInInstance(getInstance(s.ctx))
+}
+
+// OneInInstance executes the statement in the provided database instance and returns the first returned row.
+//
+// instance: the sql database instance to execute the statement in.
+// Returns a pointer of T, or nil when no row is returned, and an error.
+func (s *ReturningStatement[T]) OneInInstance(instance *sql.DB) (model *T, err error) {
+	if err = s.validate(instance); err != nil {
+		return nil, err
+	}
+
+	ctx, finish := startQueryHooks(s.ctx, STATEMENT_OPERATION, s.query, s.args)
+	defer func() {
+		if model != nil {
+			finish(1, err)
+			return
+		}
+		finish(0, err)
+	}()
+
+	rows, err := s.queryContext(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	defer closer(rows)
+
+	return getData[T](rows)
+}
+
+// Many applies the statement in the database and returns all returned rows.
+//
+// No parameters.
+// Returns a slice of T value and an error.
+func (s *ReturningStatement[T]) Many() ([]T, error) {
+	return s.ManyInInstance(getInstance(s.ctx))
+}
+
+// ManyInInstance executes the statement in the provided database instance and returns all returned rows.
+//
+// instance: the sql database instance to execute the statement in.
+// Returns a slice of T value and an error.
+func (s *ReturningStatement[T]) ManyInInstance(instance *sql.DB) (list []T, err error) {
+	if err = s.validate(instance); err != nil {
+		return nil, err
+	}
+
+	ctx, finish := startQueryHooks(s.ctx, STATEMENT_OPERATION, s.query, s.args)
+	defer func() { finish(int64(len(list)), err) }()
+
+	rows, err := s.queryContext(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	defer closer(rows)
+
+	return getDataList[T](rows)
+}
+
+// validate checks if the ReturningStatement instance is initialized and if the query is empty.
+//
+// instance: The *sql.DB instance to execute the statement.
+// Returns an error.
+func (s *ReturningStatement[T]) validate(instance *sql.DB) error {
+	if instance == nil {
+		return errors.New(db_not_initialized_error)
+	}
+
+	if s.query == "" {
+		return errors.New(query_is_empty_error)
+	}
+
+	return nil
+}
+
+// queryContext executes the statement on the provided SQL instance, or in the transaction of the context.
+//
+// ctx: the context of the execution, returned by the query hooks.
+// instance: The *sql.DB instance to execute the statement.
+// Returns the returned rows and an error.
+func (s *ReturningStatement[T]) queryContext(ctx context.Context, instance *sql.DB) (*sql.Rows, error) {
+	if tx, ok := transactionOf(ctx, instance); ok {
+		return tx.QueryContext(ctx, s.query, s.args...)
+	}
+
+	return instance.QueryContext(ctx, s.query, s.args...)
+}
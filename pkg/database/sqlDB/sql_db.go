@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/monitoring"
-	"github.com/lib/pq"
 
 	"io"
 
@@ -18,6 +17,8 @@ import (
 )
 
 const (
+	sqlDBDefaultName string = "SQL"
+
 	db_connection_success    string = "%s database connected"
 	db_connection_error      string = "An error occurred while trying to connect to the %s database. Error: %s"
 	db_migration_error       string = "An error occurred when validate database migrations: %v"
@@ -40,15 +41,34 @@ var sqlDBInstance *sql.DB
 // No parameters.
 // No return values.
 func Initialize() {
-	sqlDB := NewSQLDatabaseInstance("SQL", config.SQL_DB_CONNECTION_URI)
+	sqlDB := NewSQLDatabaseInstance(sqlDBDefaultName, config.SQL_DB_CONNECTION_URI)
 	sqlDB.SetMaxOpenConns(config.SQL_DB_MAX_OPEN_CONNS)
 	sqlDB.SetMaxIdleConns(config.SQL_DB_MAX_IDLE_CONNS)
 
-	if err := executeDatabaseMigration(sqlDB); err != nil {
+	if err := executeDatabaseMigration(sqlDB, defaultMigrationConfig()); err != nil {
 		logging.Fatal(db_migration_error, err)
 	}
 
 	sqlDBInstance = sqlDB
+	initializeReplicas(sqlDBDefaultName, sqlDB, config.SQL_DB_REPLICA_CONNECTION_URIS, config.SQL_DB_MAX_OPEN_CONNS, config.SQL_DB_MAX_IDLE_CONNS)
+	initializeDatasources()
+}
+
+// InitializeWithInstance sets an opened instance with its dialect as the default sql database and executes its migrations.
+// It's used for databases other than PostgreSQL, like the in-process SQLite of the sqlite package.
+//
+// instance: the opened sql database instance.
+// dialect: the dialect of the instance.
+// Returns an error when the migration fails.
+func InitializeWithInstance(instance *sql.DB, dialect Dialect) error {
+	SetDialect(instance, dialect)
+	if err := executeDatabaseMigration(instance, defaultMigrationConfig()); err != nil {
+		return err
+	}
+
+	sqlDBInstance = instance
+	observer.Attach(sqlDBObserver{sqlDBDefaultName, instance})
+	return nil
 }
 
 // NewSQLDatabaseInstance creates a new SQL database instance.
@@ -82,70 +102,83 @@ func (o sqlDBObserver) Close() {
 		logging.Warn("WaitGroup timed out, forcing close the %s database connection", o.name)
 	}
 	logging.Info("closing %s database connection", o.name)
+	closeStatementCache(o.instance)
 	if err := o.instance.Close(); err != nil {
 		logging.Error("error when closing %s database connection: %+v", o.name, err)
 	}
 }
 
 // getDataList retrieves a list of items from the given sql.Rows object.
+// The columns are mapped into the struct fields by db tag or snake_case field name.
 //
 // It takes a sql.Rows object as input and returns a list of items of type T and an error.
 func getDataList[T any](rows *sql.Rows) ([]T, error) {
 	list := make([]T, 0)
-	for rows.Next() {
-		model := new(T)
-		err := rows.Scan(reflectCols(model)...)
-		if err != nil {
-			return nil, err
-		}
-
+	if err := scanRows(rows, func(model *T) bool {
 		list = append(list, *model)
+		return true
+	}); err != nil {
+		return nil, err
 	}
 
 	return list, nil
 }
 
-// reflectCols generates a list of column values from the provided model.
+// getData retrieves the first item from the given sql.Rows object.
+// The columns are mapped into the struct fields by db tag or snake_case field name.
+//
+// It takes a sql.Rows object as input and returns a pointer of T, or nil when there is no row, and an error.
+func getData[T any](rows *sql.Rows) (*T, error) {
+	var result *T
+	if err := scanRows(rows, func(model *T) bool {
+		result = model
+		return false
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// scanRows scans the rows one at a time into new items of type T, until the rows end or yield returns false.
 //
-// model: the model to reflect columns from
-// []any: a list of column values
-func reflectCols(model any) (cols []any) {
-	typeOf := reflect.TypeOf(model).Elem()
-	valueOf := reflect.ValueOf(model).Elem()
-
-	isStruct, isTime, isNull, isSlice := reflectValueValidations(valueOf)
-	if isSlice {
-		cols = append(cols, pq.Array(valueOf.Addr().Interface()))
-	} else if !isStruct || isTime || isNull {
-		cols = append(cols, valueOf.Addr().Interface())
-		return
+// rows: the query result rows
+// yield: the function called with each scanned item, returning false to stop the scan
+// Returns an error.
+func scanRows[T any](rows *sql.Rows, yield func(*T) bool) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
 	}
 
-	for i := 0; i < typeOf.NumField(); i++ {
-		field := valueOf.Field(i)
+	mapper, err := newRowMapper(reflect.TypeOf((*T)(nil)).Elem(), columns)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		model := new(T)
+		if err = rows.Scan(mapper.destinations(reflect.ValueOf(model).Elem())...); err != nil {
+			return err
+		}
 
-		isStruct, isTime, isNull, isSlice = reflectValueValidations(field)
-		if isSlice {
-			cols = append(cols, pq.Array(field.Addr().Interface()))
-		} else if isStruct && !isTime && !isNull {
-			cols = append(cols, reflectCols(field.Addr().Interface())...)
-		} else {
-			cols = append(cols, field.Addr().Interface())
+		if !yield(model) {
+			return nil
 		}
 	}
 
-	return cols
+	return rows.Err()
 }
 
-// reflectValueValidations validates the type of the provided value.
+// reflectTypeValidations validates the provided type.
 //
-// value: the value to validate
-// (isStruct, isTime, isNull, isSlice) : returns booleans indicating if the value is a struct, time type, null type, or a slice.
-func reflectValueValidations(value reflect.Value) (isStruct, isTime, isNull, isSlice bool) {
-	isStruct = value.Kind() == reflect.Struct
-	isTime = slices.Contains([]string{"time.Time", "types.IsoDate", "types.IsoTime"}, value.Type().String())
-	isNull = strings.Contains(value.Type().String(), "Null")
-	isSlice = value.Kind() == reflect.Slice
+// t: the type to validate
+// (isStruct, isTime, isNull, isSlice) : returns booleans indicating if the type is a struct, time type, null type, or a slice.
+func reflectTypeValidations(t reflect.Type) (isStruct, isTime, isNull, isSlice bool) {
+	isStruct = t.Kind() == reflect.Struct
+	isTime = slices.Contains([]string{"time.Time", "types.IsoDate", "types.IsoTime"}, t.String())
+	isNull = strings.Contains(t.String(), "Null")
+	isSlice = t.Kind() == reflect.Slice
 	return
 }
 
@@ -3,29 +3,88 @@ package sqlDB
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/monitoring"
 	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/transaction"
+	"github.com/lib/pq"
 )
 
 // SqlTxContextKey is the type of the context key for the transaction.
 type SqlTxContextKey string
 
+// Propagation defines how a transaction behaves when there is already a transaction in the context.
+type Propagation int
+
+const (
+	// PROPAGATION_REQUIRED joins the transaction of the context, or starts a new one when there is none.
+	PROPAGATION_REQUIRED Propagation = iota
+	// PROPAGATION_REQUIRES_NEW always starts a new independent transaction.
+	PROPAGATION_REQUIRES_NEW
+	// PROPAGATION_NESTED creates a savepoint in the transaction of the context, or starts a new one when there is none.
+	// Its rollback only undoes the nested changes, leaving the outer transaction usable.
+	PROPAGATION_NESTED
+)
+
 const (
 	SqlTxContext SqlTxContextKey = "SqlTxContext"
 
+	savepointNameFormat          string = "colibri_savepoint_%d"
+	savepointQuery               string = "SAVEPOINT %s"
+	savepointRollbackQuery       string = "ROLLBACK TO SAVEPOINT %s"
+	savepointReleaseQuery        string = "RELEASE SAVEPOINT %s"
+	transactionSavepointErrorMsg string = "could not create transaction savepoint: %w"
+	transactionReleaseErrorMsg   string = "could not release transaction savepoint: %w"
+	transactionRetryWarnMsg      string = "retrying transaction after attempt %d of %d in %v: %v"
+	transactionRetrySegment      string = "SQL transaction retry"
+
+	serializationFailureErrorCode  pq.ErrorCode  = "40001"
+	deadlockDetectedErrorCode      pq.ErrorCode  = "40P01"
+	defaultTransactionRetryBackoff time.Duration = 50 * time.Millisecond
+	maxTransactionRetryBackoff     time.Duration = 5 * time.Second
+
 	transactionIsolationWarnMsg string = "transaction isolation just use first parameter, others will be ignored"
 	transactionRollbackErrorMsg string = "error when executing transaction rollback: %v: %w"
 	transactionCommitErrorMsg   string = "could not commit transaction: %w"
 	transactionStartErrorMsg    string = "could not start database transaction: %v"
 )
 
+// TransactionConfig is the configuration of a sql transaction
+type TransactionConfig struct {
+	// Isolation is the isolation level of the new transactions, ignored when joining a transaction.
+	Isolation sql.IsolationLevel
+	// Propagation is the behavior when there is already a transaction in the context. The default is PROPAGATION_REQUIRED.
+	Propagation Propagation
+	// MaxAttempts is the maximum number of executions of a new transaction when it fails with a serialization
+	// failure (40001) or a deadlock (40P01). Zero or one disables the retry.
+	MaxAttempts int
+	// RetryBackoff is the base delay before a retry, doubled in each retry and added by a random jitter. The default is 50ms.
+	RetryBackoff time.Duration
+}
+
 // sqlTransaction implements a transaction.Transaction
 type sqlTransaction struct {
-	isolation sql.IsolationLevel
+	isolation    sql.IsolationLevel
+	propagation  Propagation
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+// sqlTx is the transaction of the context with the database instance it belongs to.
+type sqlTx struct {
+	instance *sql.DB
+	tx       *sql.Tx
 }
 
+// savepointCounter generates the unique savepoint names of the nested transactions.
+var savepointCounter atomic.Uint64
+
 // NewTransaction creates a new sqlTransaction implementing the transaction.Transaction interface.
 //
 // It takes an optional variable number of sql.IsolationLevel parameters and returns a transaction.Transaction.
@@ -41,32 +100,111 @@ func NewTransaction(isolation ...sql.IsolationLevel) transaction.Transaction {
 	return &sqlTransaction{isolation: isolationLevel}
 }
 
+// NewTransactionWithConfig creates a new sqlTransaction with the isolation level, the propagation and the retry of the config.
+//
+// config: the transaction configuration.
+// Returns a transaction.Transaction.
+func NewTransactionWithConfig(config TransactionConfig) transaction.Transaction {
+	retryBackoff := config.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultTransactionRetryBackoff
+	}
+
+	return &sqlTransaction{
+		isolation:    config.Isolation,
+		propagation:  config.Propagation,
+		maxAttempts:  config.MaxAttempts,
+		retryBackoff: retryBackoff,
+	}
+}
+
 // Execute executes a transactional SQL.
 //
 // ctx: The context for the transaction.
 // fn: The function to be executed.
 // Returns an error.
 func (t *sqlTransaction) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
-	return t.ExecuteInInstance(ctx, sqlDBInstance, fn)
+	return t.ExecuteInInstance(ctx, getInstance(ctx), fn)
 }
 
-// ExecuteInInstance executes a transaction in a specific database instance.
+// ExecuteInInstance executes a transaction in a specific database instance, according to the propagation
+// when there is already a transaction of the instance in the context. A transaction of another instance is not joined. The transaction.OnCommit and transaction.OnRollback
+// callbacks run after the commit or the rollback of the database transaction.
 //
 // ctx: The context for the transaction.
 // instance: The specific database instance where the transaction will be executed.
 // fn: The function to be executed as part of the transaction.
 // Returns an error.
 func (t *sqlTransaction) ExecuteInInstance(ctx context.Context, instance *sql.DB, fn func(ctx context.Context) error) error {
-	transaction, transactionChannel, err := t.beginTransaction(ctx, instance)
+	if tx, ok := transactionOf(ctx, instance); ok {
+		switch t.propagation {
+		case PROPAGATION_REQUIRED:
+			return fn(ctx)
+		case PROPAGATION_NESTED:
+			return t.executeNested(ctx, tx, fn)
+		}
+	}
+
+	return t.executeNew(ctx, instance, fn)
+}
+
+// executeNew executes the function in a new transaction, retrying the whole transaction on serialization failures
+// and deadlocks until the max attempts.
+//
+// ctx: The context for the transaction.
+// instance: The specific database instance where the transaction will be executed.
+// fn: The function to be executed as part of the transaction.
+// Returns an error.
+func (t *sqlTransaction) executeNew(ctx context.Context, instance *sql.DB, fn func(ctx context.Context) error) error {
+	err := t.executeAttempt(ctx, instance, fn)
+	for attempt := 1; err != nil && attempt < t.maxAttempts && isRetryableError(err); attempt++ {
+		delay := t.retryDelay(attempt)
+		logging.Warn(transactionRetryWarnMsg, attempt, t.maxAttempts, delay, err)
+
+		segment := monitoring.StartTransactionSegment(ctx, transactionRetrySegment, map[string]string{
+			"attempt": strconv.Itoa(attempt + 1),
+			"error":   err.Error(),
+		})
+		if err = sleepContext(ctx, delay); err == nil {
+			err = t.executeAttempt(ctx, instance, fn)
+		}
+		monitoring.EndTransactionSegment(segment)
+	}
+
+	return err
+}
+
+// retryDelay returns the exponential backoff of the retry with a random jitter of up to the backoff.
+//
+// attempt: the number of the failed attempt, starting at one.
+// Returns the delay before the next attempt.
+func (t *sqlTransaction) retryDelay(attempt int) time.Duration {
+	backoff := t.retryBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > maxTransactionRetryBackoff {
+		backoff = maxTransactionRetryBackoff
+	}
+
+	return backoff + time.Duration(rand.Int63n(int64(backoff)))
+}
+
+// executeAttempt executes the function in a new transaction, committed when fn succeeds and rolled back otherwise.
+//
+// ctx: The context for the transaction.
+// instance: The specific database instance where the transaction will be executed.
+// fn: The function to be executed as part of the transaction.
+// Returns an error.
+func (t *sqlTransaction) executeAttempt(ctx context.Context, instance *sql.DB, fn func(ctx context.Context) error) error {
+	tx, transactionChannel, err := t.beginTransaction(ctx, instance)
 	if err != nil {
 		return err
 	}
 	defer close(transactionChannel)
 
-	ctx = context.WithValue(ctx, SqlTxContext, transaction)
+	txCtx, hooks := transaction.NewHooks(withTransaction(ctx, instance, tx))
 
-	if err = fn(ctx); err != nil {
-		if rbErr := transaction.Rollback(); rbErr != nil {
+	if err = fn(txCtx); err != nil {
+		defer hooks.RunRollback(ctx)
+		if rbErr := tx.Rollback(); rbErr != nil {
 			fErr := fmt.Errorf(transactionRollbackErrorMsg, err, rbErr)
 			logging.Error("%v", fErr)
 			transactionChannel <- fErr
@@ -78,13 +216,56 @@ func (t *sqlTransaction) ExecuteInInstance(ctx context.Context, instance *sql.DB
 		return err
 	}
 
-	if err = transaction.Commit(); err != nil {
+	if err = tx.Commit(); err != nil {
 		fErr := fmt.Errorf(transactionCommitErrorMsg, err)
 		logging.Error("%v", fErr)
 		transactionChannel <- fErr
+		hooks.RunRollback(ctx)
 		return fErr
 	}
 
+	hooks.RunCommit(ctx)
+	return nil
+}
+
+// executeNested executes the function inside a savepoint of the transaction, rolling back to the savepoint when fn fails.
+//
+// ctx: The context with the outer transaction.
+// tx: The outer transaction.
+// fn: The function to be executed as part of the nested transaction.
+// Returns an error.
+func (t *sqlTransaction) executeNested(ctx context.Context, tx *sql.Tx, fn func(ctx context.Context) error) error {
+	savepoint := fmt.Sprintf(savepointNameFormat, savepointCounter.Add(1))
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(savepointQuery, savepoint)); err != nil {
+		fErr := fmt.Errorf(transactionSavepointErrorMsg, err)
+		logging.Error("%v", fErr)
+		return fErr
+	}
+
+	nestedCtx, hooks := transaction.NewHooks(ctx)
+	if err := fn(nestedCtx); err != nil {
+		defer hooks.RunRollback(ctx)
+		if _, rbErr := tx.ExecContext(context.WithoutCancel(ctx), fmt.Sprintf(savepointRollbackQuery, savepoint)); rbErr != nil {
+			fErr := fmt.Errorf(transactionRollbackErrorMsg, err, rbErr)
+			logging.Error("%v", fErr)
+			return fErr
+		}
+
+		logging.Error("%v", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(savepointReleaseQuery, savepoint)); err != nil {
+		fErr := fmt.Errorf(transactionReleaseErrorMsg, err)
+		logging.Error("%v", fErr)
+		hooks.RunRollback(ctx)
+		return fErr
+	}
+
+	if outer, ok := ctx.Value(transaction.HooksContext).(*transaction.Hooks); ok {
+		outer.Merge(hooks)
+	}
+
 	return nil
 }
 
@@ -94,6 +275,10 @@ func (t *sqlTransaction) ExecuteInInstance(ctx context.Context, instance *sql.DB
 // instance: The specific database instance for the transaction.
 // Returns the transaction, a channel for errors, and an error.
 func (t *sqlTransaction) beginTransaction(ctx context.Context, instance *sql.DB) (*sql.Tx, chan error, error) {
+	if instance == nil {
+		return nil, nil, errors.New(db_not_initialized_error)
+	}
+
 	transaction, err := instance.BeginTx(ctx, &sql.TxOptions{Isolation: t.isolation})
 
 	if err != nil {
@@ -104,3 +289,74 @@ func (t *sqlTransaction) beginTransaction(ctx context.Context, instance *sql.DB)
 
 	return transaction, make(chan error, 1), nil
 }
+
+// InTransaction checks if the context contains a transaction of the datasource bound to the context.
+//
+// ctx: the context to check.
+// Returns true when the queries and statements of the context run in a transaction.
+func InTransaction(ctx context.Context) bool {
+	_, ok := transactionOf(ctx, getInstance(ctx))
+	return ok
+}
+
+// withTransaction returns a copy of ctx with the transaction of the instance.
+//
+// ctx: the parent context.
+// instance: the database instance of the transaction.
+// tx: the transaction.
+// Returns a context.Context.
+func withTransaction(ctx context.Context, instance *sql.DB, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, SqlTxContext, sqlTx{instance, tx})
+}
+
+// withoutTransaction returns a copy of ctx without the transaction, for the work that outlives the transaction.
+//
+// ctx: the parent context.
+// Returns a context.Context.
+func withoutTransaction(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SqlTxContext, nil)
+}
+
+// transactionOf returns the transaction of the context when it belongs to the instance.
+//
+// ctx: the context of the query, statement or transaction.
+// instance: the database instance where it will be executed.
+// Returns the transaction and true when the context contains a transaction of the instance.
+func transactionOf(ctx context.Context, instance *sql.DB) (*sql.Tx, bool) {
+	current, ok := ctx.Value(SqlTxContext).(sqlTx)
+	if !ok || instance == nil || current.instance != instance {
+		return nil, false
+	}
+
+	return current.tx, true
+}
+
+// isRetryableError checks if the error is a serialization failure or a deadlock, which succeed when the transaction is retried.
+//
+// err: the transaction error.
+// Returns true when the transaction can be retried.
+func isRetryableError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == serializationFailureErrorCode || pqErr.Code == deadlockDetectedErrorCode
+}
+
+// sleepContext waits for the delay or until the context is done.
+//
+// ctx: the context of the transaction.
+// delay: the time to wait.
+// Returns the context error when it's done before the delay.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -3,8 +3,13 @@ package sqlDB
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/transaction"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -89,6 +94,159 @@ func TestSqlTransaction(t *testing.T) {
 		assert.NoError(t, query2Err)
 		assert.Nil(t, query2Result)
 	})
+
+	countContact := func(email string) int {
+		count, err := NewQuery[int](ctx, "SELECT COUNT(*) FROM contacts WHERE email = $1", email).One()
+		assert.NoError(t, err)
+		return *count
+	}
+
+	insertContact := func(ctx context.Context, email string) error {
+		return NewStatement(ctx, "INSERT INTO contacts (name, email) VALUES ($1, $2)", "Propagation", email).Execute()
+	}
+
+	t.Run("Should join the outer transaction with propagation required", func(t *testing.T) {
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if err := insertContact(ctx, "required-outer@email.com"); err != nil {
+				return err
+			}
+
+			if err := NewTransactionWithConfig(TransactionConfig{Propagation: PROPAGATION_REQUIRED}).Execute(ctx, func(ctx context.Context) error {
+				return insertContact(ctx, "required-inner@email.com")
+			}); err != nil {
+				return err
+			}
+
+			return errors.New("outer fail")
+		})
+
+		assert.EqualError(t, err, "outer fail")
+		assert.Zero(t, countContact("required-outer@email.com"))
+		assert.Zero(t, countContact("required-inner@email.com"))
+	})
+
+	t.Run("Should commit the inner transaction when the outer is rolled back with propagation requires new", func(t *testing.T) {
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if err := insertContact(ctx, "requires-new-outer@email.com"); err != nil {
+				return err
+			}
+
+			if err := NewTransactionWithConfig(TransactionConfig{Propagation: PROPAGATION_REQUIRES_NEW}).Execute(ctx, func(ctx context.Context) error {
+				return insertContact(ctx, "requires-new-inner@email.com")
+			}); err != nil {
+				return err
+			}
+
+			return errors.New("outer fail")
+		})
+
+		assert.EqualError(t, err, "outer fail")
+		assert.Zero(t, countContact("requires-new-outer@email.com"))
+		assert.Equal(t, 1, countContact("requires-new-inner@email.com"))
+	})
+
+	t.Run("Should rollback only the savepoint with propagation nested", func(t *testing.T) {
+		nested := NewTransactionWithConfig(TransactionConfig{Propagation: PROPAGATION_NESTED})
+		var nestedErr error
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if err := insertContact(ctx, "nested-outer@email.com"); err != nil {
+				return err
+			}
+
+			nestedErr = nested.Execute(ctx, func(ctx context.Context) error {
+				if err := insertContact(ctx, "nested-inner@email.com"); err != nil {
+					return err
+				}
+				return insertContact(ctx, "nested-outer@email.com")
+			})
+
+			return nested.Execute(ctx, func(ctx context.Context) error {
+				return insertContact(ctx, "nested-released@email.com")
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.Error(t, nestedErr)
+		assert.Equal(t, 1, countContact("nested-outer@email.com"))
+		assert.Zero(t, countContact("nested-inner@email.com"))
+		assert.Equal(t, 1, countContact("nested-released@email.com"))
+	})
+
+	t.Run("Should retry the transaction on serialization failure", func(t *testing.T) {
+		attempts := 0
+		err := NewTransactionWithConfig(TransactionConfig{MaxAttempts: 3, RetryBackoff: time.Millisecond}).Execute(ctx, func(ctx context.Context) error {
+			attempts++
+			if err := insertContact(ctx, "retry@email.com"); err != nil {
+				return err
+			}
+
+			if attempts < 3 {
+				return &pq.Error{Code: serializationFailureErrorCode}
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, 1, countContact("retry@email.com"))
+	})
+
+	t.Run("Should return error when the max attempts is reached", func(t *testing.T) {
+		attempts := 0
+		err := NewTransactionWithConfig(TransactionConfig{MaxAttempts: 2, RetryBackoff: time.Millisecond}).Execute(ctx, func(ctx context.Context) error {
+			attempts++
+			return &pq.Error{Code: deadlockDetectedErrorCode}
+		})
+
+		assert.True(t, isRetryableError(err))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("Should run commit hooks after commit", func(t *testing.T) {
+		var count int
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			transaction.OnCommit(ctx, func(ctx context.Context) { count = countContact("hook-commit@email.com") })
+			transaction.OnRollback(ctx, func(ctx context.Context) { count = -1 })
+			return insertContact(ctx, "hook-commit@email.com")
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Should run rollback hooks after rollback", func(t *testing.T) {
+		committed, rolledBack := false, false
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			transaction.OnCommit(ctx, func(ctx context.Context) { committed = true })
+			transaction.OnRollback(ctx, func(ctx context.Context) { rolledBack = true })
+			return errors.New("fail")
+		})
+
+		assert.Error(t, err)
+		assert.False(t, committed)
+		assert.True(t, rolledBack)
+	})
+
+	t.Run("Should run nested hooks with the outer transaction", func(t *testing.T) {
+		calls := []string{}
+		nested := NewTransactionWithConfig(TransactionConfig{Propagation: PROPAGATION_NESTED})
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			_ = nested.Execute(ctx, func(ctx context.Context) error {
+				transaction.OnCommit(ctx, func(ctx context.Context) { calls = append(calls, "failed nested commit") })
+				transaction.OnRollback(ctx, func(ctx context.Context) { calls = append(calls, "failed nested rollback") })
+				return errors.New("nested fail")
+			})
+
+			return nested.Execute(ctx, func(ctx context.Context) error {
+				transaction.OnCommit(ctx, func(ctx context.Context) { calls = append(calls, "nested commit") })
+				calls = append(calls, "nested executed")
+				return nil
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"failed nested rollback", "nested executed", "nested commit"}, calls)
+	})
 }
 
 func TestSqlTransactionIsolationLevel(t *testing.T) {
@@ -113,3 +271,152 @@ func TestSqlTransactionIsolationLevel(t *testing.T) {
 		assert.Equal(t, sql.LevelLinearizable, tx.(*sqlTransaction).isolation)
 	})
 }
+
+func TestSqlTransactionPropagation(t *testing.T) {
+	t.Run("Should return propagation required as default", func(t *testing.T) {
+		tx := NewTransaction()
+
+		assert.Equal(t, PROPAGATION_REQUIRED, tx.(*sqlTransaction).propagation)
+	})
+
+	t.Run("Should return transaction with config", func(t *testing.T) {
+		tx := NewTransactionWithConfig(TransactionConfig{Isolation: sql.LevelSerializable, Propagation: PROPAGATION_NESTED})
+
+		assert.Equal(t, sql.LevelSerializable, tx.(*sqlTransaction).isolation)
+		assert.Equal(t, PROPAGATION_NESTED, tx.(*sqlTransaction).propagation)
+	})
+}
+
+func TestSqlTransactionRetry(t *testing.T) {
+	t.Run("Should not retry by default", func(t *testing.T) {
+		tx := NewTransaction()
+
+		assert.Zero(t, tx.(*sqlTransaction).maxAttempts)
+	})
+
+	t.Run("Should return default retry backoff", func(t *testing.T) {
+		tx := NewTransactionWithConfig(TransactionConfig{MaxAttempts: 3})
+
+		assert.Equal(t, 3, tx.(*sqlTransaction).maxAttempts)
+		assert.Equal(t, defaultTransactionRetryBackoff, tx.(*sqlTransaction).retryBackoff)
+	})
+
+	t.Run("Should return retry delay with exponential backoff and jitter", func(t *testing.T) {
+		tx := NewTransactionWithConfig(TransactionConfig{MaxAttempts: 3, RetryBackoff: 10 * time.Millisecond}).(*sqlTransaction)
+
+		assert.GreaterOrEqual(t, tx.retryDelay(1), 10*time.Millisecond)
+		assert.Less(t, tx.retryDelay(1), 20*time.Millisecond)
+		assert.GreaterOrEqual(t, tx.retryDelay(3), 40*time.Millisecond)
+		assert.Less(t, tx.retryDelay(3), 80*time.Millisecond)
+		assert.Less(t, tx.retryDelay(100), 2*maxTransactionRetryBackoff)
+	})
+
+	t.Run("Should check retryable errors", func(t *testing.T) {
+		assert.True(t, isRetryableError(&pq.Error{Code: serializationFailureErrorCode}))
+		assert.True(t, isRetryableError(fmt.Errorf(transactionCommitErrorMsg, &pq.Error{Code: deadlockDetectedErrorCode})))
+		assert.False(t, isRetryableError(&pq.Error{Code: "23505"}))
+		assert.False(t, isRetryableError(errors.New("any error")))
+	})
+
+	t.Run("Should stop waiting when context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, sleepContext(ctx, time.Minute), context.Canceled)
+	})
+}
+
+func TestSqlTransactionDatasources(t *testing.T) {
+	ctx := context.Background()
+	const insertQuery = "INSERT INTO contacts (name) VALUES ('contact')"
+
+	setup := func(t *testing.T) (*sql.DB, *sql.DB) {
+		primary, reporting := openStatementCacheTestDB(t), openStatementCacheTestDB(t)
+		sqlDBInstance = primary
+		datasources["REPORTING"] = reporting
+		t.Cleanup(func() {
+			sqlDBInstance = nil
+			delete(datasources, "REPORTING")
+		})
+
+		return primary, reporting
+	}
+
+	countContacts := func(instance *sql.DB) int {
+		var count int
+		assert.NoError(t, instance.QueryRow("SELECT COUNT(*) FROM contacts").Scan(&count))
+		return count
+	}
+
+	t.Run("Should start a new transaction in another datasource with propagation required", func(t *testing.T) {
+		primary, reporting := setup(t)
+
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if err := NewStatement(ctx, insertQuery).Execute(); err != nil {
+				return err
+			}
+
+			if err := NewTransaction().Execute(WithDatasource(ctx, "REPORTING"), func(ctx context.Context) error {
+				return NewStatement(ctx, insertQuery).Execute()
+			}); err != nil {
+				return err
+			}
+
+			return errors.New("outer fail")
+		})
+
+		assert.EqualError(t, err, "outer fail")
+		assert.Zero(t, countContacts(primary))
+		assert.Equal(t, 1, countContacts(reporting))
+	})
+
+	t.Run("Should not join the transaction of another instance", func(t *testing.T) {
+		primary, reporting := setup(t)
+
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			nested := NewTransactionWithConfig(TransactionConfig{Propagation: PROPAGATION_NESTED}).(*sqlTransaction)
+			if err := nested.ExecuteInInstance(ctx, reporting, func(ctx context.Context) error {
+				return NewStatement(ctx, insertQuery).ExecuteInInstance(reporting)
+			}); err != nil {
+				return err
+			}
+
+			return errors.New("outer fail")
+		})
+
+		assert.EqualError(t, err, "outer fail")
+		assert.Zero(t, countContacts(primary))
+		assert.Equal(t, 1, countContacts(reporting))
+	})
+	t.Run("Should execute queries and statements of another datasource out of the transaction", func(t *testing.T) {
+		primary, reporting := setup(t)
+
+		err := NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			reportingCtx := WithDatasource(ctx, "REPORTING")
+			assert.True(t, InTransaction(ctx))
+			assert.False(t, InTransaction(reportingCtx))
+			assert.True(t, InTransaction(WithDatasource(reportingCtx, "")))
+
+			if err := NewStatement(ctx, insertQuery).Execute(); err != nil {
+				return err
+			}
+
+			if err := NewStatement(reportingCtx, insertQuery).Execute(); err != nil {
+				return err
+			}
+
+			count, err := NewQuery[int](reportingCtx, "SELECT COUNT(*) FROM contacts").One()
+			assert.NoError(t, err)
+			assert.Equal(t, 1, *count)
+
+			count, err = NewQuery[int](ctx, "SELECT COUNT(*) FROM contacts").One()
+			assert.NoError(t, err)
+			assert.Equal(t, 1, *count)
+			return errors.New("outer fail")
+		})
+
+		assert.EqualError(t, err, "outer fail")
+		assert.Zero(t, countContacts(primary))
+		assert.Equal(t, 1, countContacts(reporting))
+	})
+}
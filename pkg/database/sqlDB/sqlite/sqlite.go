@@ -0,0 +1,129 @@
+// Package sqlite provides the SQLite dialect of sqlDB, with the in-process modernc.org/sqlite driver.
+// It's used to run the sqlDB queries, statements, transactions and repositories in unit tests without a
+// PostgreSQL container.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/database/sqlDB"
+	"github.com/golang-migrate/migrate/v4/database"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	driverName  string = "sqlite"
+	dialectName string = "sqlite"
+
+	// MemoryDSN is the data source name of a private in-memory database.
+	MemoryDSN string = ":memory:"
+
+	pageTotalQuery string = "SELECT COUNT(*) FROM (%s) tb"
+	pageDataQuery  string = "%s ORDER BY %s LIMIT %d OFFSET %d"
+)
+
+// Dialect is the SQLite dialect. The arrays are stored as JSON text.
+var Dialect sqlDB.Dialect = sqliteDialect{}
+
+// Open opens a SQLite database and sets its dialect. The database has a single connection, because SQLite
+// allows one writer at a time and each connection of an in-memory database is a different database.
+//
+// dsn: the data source name, ex: MemoryDSN or file:test.db
+// Returns a pointer to sql.DB and an error.
+func Open(dsn string) (*sql.DB, error) {
+	instance, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	instance.SetMaxOpenConns(1)
+
+	if err = instance.Ping(); err != nil {
+		return nil, err
+	}
+
+	sqlDB.SetDialect(instance, Dialect)
+	return instance, nil
+}
+
+// Initialize opens a SQLite database as the default sqlDB instance and executes the migrations,
+// when SQL_DB_MIGRATION is enabled.
+//
+// dsn: the data source name, ex: MemoryDSN or file:test.db
+// Returns an error.
+func Initialize(dsn string) error {
+	instance, err := Open(dsn)
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.InitializeWithInstance(instance, Dialect)
+}
+
+// sqliteDialect implements the sqlDB.Dialect of SQLite.
+type sqliteDialect struct{}
+
+// Name returns sqlite.
+func (sqliteDialect) Name() string {
+	return dialectName
+}
+
+// Placeholder returns ?<position>.
+func (sqliteDialect) Placeholder(position int) string {
+	return fmt.Sprintf("?%d", position)
+}
+
+// CountQuery returns the query counting the rows of the query as subquery.
+func (sqliteDialect) CountQuery(query string) string {
+	return fmt.Sprintf(pageTotalQuery, query)
+}
+
+// PageQuery returns the query with ORDER BY, LIMIT and OFFSET.
+func (sqliteDialect) PageQuery(query string, order string, limit, offset int) string {
+	return fmt.Sprintf(pageDataQuery, query, order, limit, offset)
+}
+
+// ArrayArgument returns the slice encoded as JSON text.
+func (sqliteDialect) ArrayArgument(value any) any {
+	return jsonArray{value}
+}
+
+// CopyIn returns false, SQLite has no bulk copy.
+func (sqliteDialect) CopyIn(string, []string) (string, bool) {
+	return "", false
+}
+
+// MigrationDriver returns the sqlite migration driver of the instance. The instance is not closed with the driver.
+func (sqliteDialect) MigrationDriver(_ context.Context, instance *sql.DB, _ string) (database.Driver, io.Closer, error) {
+	driver, err := migratesqlite.WithInstance(instance, &migratesqlite.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return driver, nil, nil
+}
+
+// jsonArray is a slice argument encoded as JSON text.
+type jsonArray struct {
+	value any
+}
+
+// Value implements the driver.Valuer interface. A nil slice is NULL.
+func (a jsonArray) Value() (driver.Value, error) {
+	if value := reflect.ValueOf(a.value); !value.IsValid() || (value.Kind() == reflect.Slice && value.IsNil()) {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(a.value)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
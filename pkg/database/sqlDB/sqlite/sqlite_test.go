@@ -0,0 +1,176 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/test"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/database/sqlDB"
+	"github.com/stretchr/testify/assert"
+)
+
+type contact struct {
+	ID    int      `db:"id,pk,auto"`
+	Name  string   `db:"name"`
+	Email string   `db:"email"`
+	Tags  []string `db:"tags"`
+}
+
+func (contact) TableName() string {
+	return "contacts"
+}
+
+type operationsQueryHook struct {
+	operations []string
+	rows       []int64
+}
+
+func (h *operationsQueryHook) Before(ctx context.Context, _ sqlDB.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *operationsQueryHook) After(_ context.Context, event sqlDB.QueryEvent) {
+	h.operations = append(h.operations, event.Operation)
+	h.rows = append(h.rows, event.Rows)
+}
+
+func initializeSqliteTest(t *testing.T) {
+	test.InitializeBaseTest()
+	config.SQL_DB_MIGRATION = true
+	sqlDB.RegisterMigrations(fstest.MapFS{
+		"migrations/000001_contacts.up.sql":   {Data: []byte("CREATE TABLE contacts (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, email TEXT UNIQUE NOT NULL, tags TEXT)")},
+		"migrations/000001_contacts.down.sql": {Data: []byte("DROP TABLE contacts")},
+	}, "migrations")
+	t.Cleanup(func() { config.SQL_DB_MIGRATION = false })
+
+	assert.NoError(t, Initialize(MemoryDSN))
+}
+
+func TestSqliteDialect(t *testing.T) {
+	t.Run("Should return sqlite syntax", func(t *testing.T) {
+		assert.Equal(t, "sqlite", Dialect.Name())
+		assert.Equal(t, "?2", Dialect.Placeholder(2))
+		assert.Equal(t, "SELECT COUNT(*) FROM (SELECT * FROM contacts) tb", Dialect.CountQuery("SELECT * FROM contacts"))
+		assert.Equal(t, "SELECT * FROM contacts ORDER BY name ASC LIMIT 10 OFFSET 20", Dialect.PageQuery("SELECT * FROM contacts", "name ASC", 10, 20))
+
+		_, isCopy := Dialect.CopyIn("contacts", []string{"name"})
+		assert.False(t, isCopy)
+	})
+
+	t.Run("Should encode arrays as JSON", func(t *testing.T) {
+		value, err := Dialect.ArrayArgument([]string{"a", "b"}).(jsonArray).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `["a","b"]`, value)
+
+		value, err = Dialect.ArrayArgument([]string(nil)).(jsonArray).Value()
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestSqlite(t *testing.T) {
+	initializeSqliteTest(t)
+	ctx := context.Background()
+	repository := sqlDB.NewRepository[contact, int]()
+
+	t.Run("Should apply the migrations", func(t *testing.T) {
+		status, err := sqlDB.NewMigrator(ctx).Status()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1}, status.Applied)
+	})
+
+	t.Run("Should insert, find, update and delete with repository", func(t *testing.T) {
+		model := &contact{Name: "Contact", Email: "contact@email.com", Tags: []string{"a", "b"}}
+		assert.NoError(t, repository.Insert(ctx, model))
+		assert.NotZero(t, model.ID)
+
+		model.Name = "Contact Updated"
+		assert.NoError(t, repository.Update(ctx, model))
+
+		result, err := repository.FindByID(ctx, model.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, model, result)
+
+		assert.NoError(t, repository.Delete(ctx, model.ID))
+		result, err = repository.FindByID(ctx, model.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should bulk insert and return page", func(t *testing.T) {
+		count, err := sqlDB.NewBulkInsert[contact](ctx).Execute([]contact{
+			{Name: "Bulk 1", Email: "bulk1@email.com"},
+			{Name: "Bulk 2", Email: "bulk2@email.com"},
+			{Name: "Bulk 3", Email: "bulk3@email.com"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		page, err := repository.FindAll(ctx, types.NewPageRequest(1, 2, []types.Sort{types.NewSort(types.DESC, "name")}))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), page.TotalElements)
+		assert.Equal(t, []string{"Bulk 3", "Bulk 2"}, []string{page.Content[0].Name, page.Content[1].Name})
+	})
+
+	t.Run("Should bind named params", func(t *testing.T) {
+		result, err := sqlDB.NewNamedQuery[contact](ctx, "SELECT * FROM contacts WHERE email = :email", map[string]any{"email": "bulk1@email.com"}).One()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Bulk 1", result.Name)
+	})
+
+	t.Run("Should call query hooks", func(t *testing.T) {
+		hook := &operationsQueryHook{}
+		sqlDB.AddQueryHook(hook)
+
+		assert.NoError(t, sqlDB.NewStatement(ctx, "UPDATE contacts SET name = name WHERE email LIKE $1", "bulk%").Execute())
+		_, err := sqlDB.NewQuery[contact](ctx, "SELECT * FROM contacts").Many()
+		assert.NoError(t, err)
+		_, err = sqlDB.NewPageQuery[contact](ctx, types.NewPageRequest(1, 2, []types.Sort{types.NewSort(types.ASC, "name")}), "SELECT * FROM contacts").Execute()
+		assert.NoError(t, err)
+
+		_, err = sqlDB.NewCursorQuery[contact](ctx, types.NewPageRequest(1, 2, []types.Sort{types.NewSort(types.ASC, "id")}), "", "SELECT * FROM contacts").Execute()
+		assert.NoError(t, err)
+		_, err = sqlDB.NewReturningStatement[contact](ctx, "UPDATE contacts SET name = name WHERE email LIKE $1 RETURNING *", "bulk%").Many()
+		assert.NoError(t, err)
+		_, err = sqlDB.NewBulkInsert[contact](ctx).Execute([]contact{{Name: "Hook", Email: "hook@email.com"}})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{
+			sqlDB.STATEMENT_OPERATION, sqlDB.QUERY_OPERATION, sqlDB.PAGE_QUERY_OPERATION, sqlDB.PAGE_QUERY_OPERATION,
+			sqlDB.CURSOR_QUERY_OPERATION, sqlDB.STATEMENT_OPERATION, sqlDB.STATEMENT_OPERATION,
+		}, hook.operations)
+		assert.Equal(t, []int64{3, 3, 1, 2, 3, 3, 1}, hook.rows)
+	})
+
+	t.Run("Should rollback transaction", func(t *testing.T) {
+		err := sqlDB.NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if err := repository.Insert(ctx, &contact{Name: "Rollback", Email: "rollback@email.com"}); err != nil {
+				return err
+			}
+			return errors.New("rollback")
+		})
+		result, queryErr := sqlDB.NewQuery[contact](ctx, "SELECT * FROM contacts WHERE email = $1", "rollback@email.com").One()
+
+		assert.EqualError(t, err, "rollback")
+		assert.NoError(t, queryErr)
+		assert.Nil(t, result)
+	})
+	t.Run("Should return error when a cursor order column is null", func(t *testing.T) {
+		type contactNickname struct {
+			ID       int     `db:"id"`
+			Nickname *string `db:"nickname"`
+		}
+		order := []types.Sort{types.NewSort(types.ASC, "nickname"), types.NewSort(types.ASC, "id")}
+
+		result, err := sqlDB.NewCursorQuery[contactNickname](ctx, types.NewPageRequest(1, 2, order), "", "SELECT id, NULL AS nickname FROM contacts").Execute()
+
+		assert.EqualError(t, err, "cursor query order field nickname is null, the order columns must be NOT NULL")
+		assert.Nil(t, result)
+	})
+}
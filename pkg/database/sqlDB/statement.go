@@ -4,13 +4,22 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 )
 
+const (
+	unexpected_rows_affected_error string = "%w: expected %d rows, affected %d"
+)
+
+// ErrUnexpectedRowsAffected is returned when a statement does not affect the expected number of rows.
+var ErrUnexpectedRowsAffected = errors.New("unexpected rows affected")
+
 // Statement is a struct for sql statement
 type Statement struct {
 	ctx   context.Context
 	query string
 	args  []interface{}
+	err   error
 }
 
 // NewStatement creates a new pointer to Statement struct.
@@ -20,7 +29,7 @@ type Statement struct {
 // params: variadic interface{} for additional parameters
 // Returns a pointer to Statement struct
 func NewStatement(ctx context.Context, query string, params ...interface{}) *Statement {
-	return &Statement{ctx, query, params}
+	return &Statement{ctx: ctx, query: query, args: params}
 }
 
 // Execute applies the statement in the database.
@@ -28,7 +37,7 @@ func NewStatement(ctx context.Context, query string, params ...interface{}) *Sta
 // No parameters.
 // Returns an error.
 func (s *Statement) Execute() error {
-	return s.ExecuteInInstance(sqlDBInstance)
+	return s.ExecuteInInstance(getInstance(s.ctx))
 }
 
 // ExecuteInInstance executes the statement in the provided database instance.
@@ -36,28 +45,93 @@ func (s *Statement) Execute() error {
 // instance: the sql database instance to execute the statement in.
 // Returns an error.
 func (s *Statement) ExecuteInInstance(instance *sql.DB) error {
-	if err := s.validate(instance); err != nil {
-		return err
+	_, err := s.exec(instance)
+	return err
+}
+
+// ExecuteRowsAffected applies the statement in the database and returns the number of rows affected.
+//
+// No parameters.
+// Returns the number of rows affected and an error.
+func (s *Statement) ExecuteRowsAffected() (int64, error) {
+	return s.ExecuteRowsAffectedInInstance(getInstance(s.ctx))
+}
+
+// ExecuteRowsAffectedInInstance executes the statement in the provided database instance and returns the number of rows affected.
+//
+// instance: the sql database instance to execute the statement in.
+// Returns the number of rows affected and an error.
+func (s *Statement) ExecuteRowsAffectedInInstance(instance *sql.DB) (int64, error) {
+	result, err := s.exec(instance)
+	if err != nil {
+		return 0, err
 	}
 
-	stmt, err := s.createStatement(instance)
+	return result.RowsAffected()
+}
+
+// ExecuteExpecting applies the statement in the database and checks the number of rows affected.
+// It's used to detect not found updates and optimistic locking conflicts.
+//
+// expected: the number of rows the statement must affect.
+// Returns an error wrapping ErrUnexpectedRowsAffected when the number of rows affected is different.
+func (s *Statement) ExecuteExpecting(expected int64) error {
+	return s.ExecuteExpectingInInstance(getInstance(s.ctx), expected)
+}
+
+// ExecuteExpectingInInstance executes the statement in the provided database instance and checks the number of rows affected.
+//
+// instance: the sql database instance to execute the statement in.
+// expected: the number of rows the statement must affect.
+// Returns an error wrapping ErrUnexpectedRowsAffected when the number of rows affected is different.
+func (s *Statement) ExecuteExpectingInInstance(instance *sql.DB, expected int64) error {
+	affected, err := s.ExecuteRowsAffectedInInstance(instance)
 	if err != nil {
 		return err
 	}
-	defer closer(stmt)
 
-	if _, err = stmt.Exec(s.args...); err != nil {
-		return err
+	if affected != expected {
+		return fmt.Errorf(unexpected_rows_affected_error, ErrUnexpectedRowsAffected, expected, affected)
 	}
 
 	return nil
 }
 
-// validate checks if the Statement instance is initialized and if the query is empty.
+// exec validates and executes the statement in the provided database instance.
+//
+// instance: the sql database instance to execute the statement in.
+// Returns the sql.Result and an error.
+func (s *Statement) exec(instance *sql.DB) (result sql.Result, err error) {
+	if err = s.validate(instance); err != nil {
+		return nil, err
+	}
+
+	ctx, finish := startQueryHooks(s.ctx, STATEMENT_OPERATION, s.query, s.args)
+	defer func() { finish(rowsAffected(result), err) }()
+
+	stmt, release, err := s.createStatement(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result, err = stmt.ExecContext(ctx, s.args...)
+	if cache := statementCacheOf(instance); cache != nil && isConnectionError(err) {
+		cache.invalidate(s.query)
+	}
+
+	return result, err
+}
+
+// validate checks if the Statement instance is initialized, if the query is empty and if the named params are valid.
 //
 // No parameters.
 // Returns an error.
 func (s *Statement) validate(instance *sql.DB) error {
+	if s.err != nil {
+		return s.err
+	}
+
 	if instance == nil {
 		return errors.New(db_not_initialized_error)
 	}
@@ -69,14 +143,64 @@ func (s *Statement) validate(instance *sql.DB) error {
 	return nil
 }
 
-// createStatement creates a SQL statement for execution.
+// createStatement returns the prepared statement for execution, from the prepared statement cache of the instance when it's enabled.
+// Inside a transaction the cached statement is bound to the transaction with StmtContext. A statement not cached yet
+// is prepared in the transaction, when the instance has no free connection to prepare it for the cache.
 //
-// No parameters.
-// Returns a pointer to sql.Stmt and an error.
-func (s *Statement) createStatement(instance *sql.DB) (*sql.Stmt, error) {
-	if tx := s.ctx.Value(SqlTxContext); tx != nil {
-		return tx.(*sql.Tx).PrepareContext(s.ctx, s.query)
+// ctx: the context of the execution, returned by the query hooks.
+// instance: the sql database instance to execute the statement in.
+// Returns a pointer to sql.Stmt, the function to release it after the execution and an error.
+func (s *Statement) createStatement(ctx context.Context, instance *sql.DB) (*sql.Stmt, func(), error) {
+	tx, inTx := transactionOf(ctx, instance)
+	cache := statementCacheOf(instance)
+	if cache == nil || (inTx && !cache.contains(s.query) && !hasFreeConnection(instance)) {
+		return s.prepareStatement(ctx, instance, tx)
+	}
+
+	entry, err := cache.acquire(ctx, instance, s.query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !inTx {
+		return entry.stmt, func() { cache.release(entry) }, nil
+	}
+
+	stmt := tx.StmtContext(ctx, entry.stmt)
+	return stmt, func() {
+		closer(stmt)
+		cache.release(entry)
+	}, nil
+}
+
+// prepareStatement prepares a statement not cached, in the transaction when there is one.
+//
+// ctx: the context of the execution.
+// instance: the sql database instance to execute the statement in.
+// tx: the transaction of the context, or nil.
+// Returns a pointer to sql.Stmt, the function to close it after the execution and an error.
+func (s *Statement) prepareStatement(ctx context.Context, instance *sql.DB, tx *sql.Tx) (stmt *sql.Stmt, release func(), err error) {
+	if tx != nil {
+		stmt, err = tx.PrepareContext(ctx, s.query)
+	} else {
+		stmt, err = instance.PrepareContext(ctx, s.query)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stmt, func() { closer(stmt) }, nil
+}
+
+// rowsAffected returns the number of rows affected of the result, or zero when it's not available.
+//
+// result: the sql.Result of the statement, may be nil.
+// Returns the number of rows affected.
+func rowsAffected(result sql.Result) int64 {
+	if result == nil {
+		return 0
 	}
 
-	return instance.PrepareContext(s.ctx, s.query)
+	affected, _ := result.RowsAffected()
+	return affected
 }
@@ -0,0 +1,203 @@
+package sqlDB
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+)
+
+// statementCaches contains the prepared statement cache of each instance.
+var statementCaches sync.Map
+
+// statementCache is a LRU cache of prepared statements keyed by query text.
+type statementCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+// cachedStatement is a prepared statement of the cache. It's closed when it's evicted and no execution is using it.
+type cachedStatement struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+// statementCacheOf returns the prepared statement cache of the instance, creating it with the SQL_DB_STATEMENT_CACHE_SIZE capacity.
+//
+// instance: the sql database instance.
+// Returns a pointer to statementCache, or nil when the cache is disabled.
+func statementCacheOf(instance *sql.DB) *statementCache {
+	if cache, ok := statementCaches.Load(instance); ok {
+		return cache.(*statementCache)
+	}
+
+	if config.SQL_DB_STATEMENT_CACHE_SIZE <= 0 {
+		return nil
+	}
+
+	cache, _ := statementCaches.LoadOrStore(instance, newStatementCache(config.SQL_DB_STATEMENT_CACHE_SIZE))
+	return cache.(*statementCache)
+}
+
+// closeStatementCache closes the prepared statements of the instance and removes its cache.
+//
+// instance: the sql database instance.
+// No return values.
+func closeStatementCache(instance *sql.DB) {
+	if cache, ok := statementCaches.LoadAndDelete(instance); ok {
+		cache.(*statementCache).clear()
+	}
+}
+
+// newStatementCache creates a new pointer to statementCache.
+//
+// capacity: the maximum number of prepared statements.
+// Returns a pointer to statementCache.
+func newStatementCache(capacity int) *statementCache {
+	return &statementCache{capacity: capacity, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// acquire returns the cached prepared statement of the query, preparing it in the instance on a cache miss.
+// The least recently used statement is evicted when the cache is full. The statement must be released after use.
+//
+// ctx: the context of the execution.
+// instance: the sql database instance to prepare the statement in.
+// query: the query text of the statement.
+// Returns a pointer to cachedStatement and an error.
+func (c *statementCache) acquire(ctx context.Context, instance *sql.DB, query string) (*cachedStatement, error) {
+	c.mu.Lock()
+	if element, ok := c.entries[query]; ok {
+		c.order.MoveToFront(element)
+		entry := element.Value.(*cachedStatement)
+		entry.refs++
+		c.mu.Unlock()
+		return entry, nil
+	}
+	c.mu.Unlock()
+
+	stmt, err := instance.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[query]; ok {
+		// prepared concurrently by another execution
+		closer(stmt)
+		c.order.MoveToFront(element)
+		entry := element.Value.(*cachedStatement)
+		entry.refs++
+		return entry, nil
+	}
+
+	entry := &cachedStatement{query: query, stmt: stmt, refs: 1}
+	c.entries[query] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		c.evict(c.order.Back())
+	}
+
+	return entry, nil
+}
+
+// contains checks if the statement of the query is cached.
+//
+// query: the query text of the statement.
+// Returns true when the statement is cached.
+func (c *statementCache) contains(query string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.entries[query]
+	return ok
+}
+
+// release ends the use of the statement, closing it when it was evicted.
+//
+// entry: the statement returned by acquire.
+// No return values.
+func (c *statementCache) release(entry *cachedStatement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.refs--
+	if entry.evicted && entry.refs == 0 {
+		closer(entry.stmt)
+	}
+}
+
+// invalidate removes the statement of the query from the cache.
+//
+// query: the query text of the statement.
+// No return values.
+func (c *statementCache) invalidate(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[query]; ok {
+		c.evict(element)
+	}
+}
+
+// clear removes all the statements from the cache.
+//
+// No parameters.
+// No return values.
+func (c *statementCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.order.Len() > 0 {
+		c.evict(c.order.Back())
+	}
+}
+
+// evict removes the element from the cache and closes its statement when no execution is using it.
+// The caller must hold the cache lock.
+//
+// element: the list element of the statement.
+// No return values.
+func (c *statementCache) evict(element *list.Element) {
+	entry := c.order.Remove(element).(*cachedStatement)
+	delete(c.entries, entry.query)
+
+	entry.evicted = true
+	if entry.refs == 0 {
+		closer(entry.stmt)
+	}
+}
+
+// hasFreeConnection checks if the instance can provide a connection without waiting for the ones in use.
+//
+// instance: the sql database instance.
+// Returns true when there is an idle connection or the open connections limit is not reached.
+func hasFreeConnection(instance *sql.DB) bool {
+	stats := instance.Stats()
+	return stats.Idle > 0 || stats.MaxOpenConnections == 0 || stats.OpenConnections < stats.MaxOpenConnections
+}
+
+// isConnectionError checks if the error is a broken or closed connection error, which invalidates the prepared statement.
+//
+// err: the error of the execution.
+// Returns true when it's a connection error.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &netErr)
+}
@@ -0,0 +1,155 @@
+package sqlDB
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+)
+
+func openStatementCacheTestDB(t *testing.T) *sql.DB {
+	instance, err := sql.Open("sqlite", ":memory:")
+	assert.NoError(t, err)
+	instance.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		closeStatementCache(instance)
+		instance.Close()
+	})
+
+	_, err = instance.Exec("CREATE TABLE contacts (id INTEGER PRIMARY KEY, name TEXT)")
+	assert.NoError(t, err)
+	return instance
+}
+
+func TestStatementCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should evict the least recently used statement", func(t *testing.T) {
+		instance := openStatementCacheTestDB(t)
+		cache := newStatementCache(2)
+
+		for _, query := range []string{"SELECT 1", "SELECT 2", "SELECT 1", "SELECT 3"} {
+			entry, err := cache.acquire(ctx, instance, query)
+			assert.NoError(t, err)
+			cache.release(entry)
+		}
+
+		assert.True(t, cache.contains("SELECT 1"))
+		assert.False(t, cache.contains("SELECT 2"))
+		assert.True(t, cache.contains("SELECT 3"))
+	})
+
+	t.Run("Should return the same statement while it's cached", func(t *testing.T) {
+		instance := openStatementCacheTestDB(t)
+		cache := newStatementCache(2)
+
+		first, err := cache.acquire(ctx, instance, "SELECT 1")
+		assert.NoError(t, err)
+		second, err := cache.acquire(ctx, instance, "SELECT 1")
+		assert.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, 2, first.refs)
+	})
+
+	t.Run("Should close an evicted statement only after it's released", func(t *testing.T) {
+		instance := openStatementCacheTestDB(t)
+		cache := newStatementCache(1)
+
+		entry, err := cache.acquire(ctx, instance, "SELECT 1")
+		assert.NoError(t, err)
+		cache.invalidate("SELECT 1")
+
+		_, err = entry.stmt.ExecContext(ctx)
+		assert.NoError(t, err)
+
+		cache.release(entry)
+		_, err = entry.stmt.ExecContext(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should close all statements when the cache is closed", func(t *testing.T) {
+		instance := openStatementCacheTestDB(t)
+		cache := newStatementCache(2)
+		entry, err := cache.acquire(ctx, instance, "SELECT 1")
+		assert.NoError(t, err)
+		cache.release(entry)
+
+		cache.clear()
+
+		assert.False(t, cache.contains("SELECT 1"))
+		_, err = entry.stmt.ExecContext(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestStatementWithCache(t *testing.T) {
+	ctx := context.Background()
+	query := "INSERT INTO contacts (name) VALUES ($1)"
+
+	t.Run("Should cache the statement executed in the instance", func(t *testing.T) {
+		instance := openStatementCacheTestDB(t)
+
+		assert.NoError(t, NewStatement(ctx, query, "contact 1").ExecuteInInstance(instance))
+		assert.NoError(t, NewStatement(ctx, query, "contact 2").ExecuteInInstance(instance))
+
+		assert.True(t, statementCacheOf(instance).contains(query))
+		assert.Equal(t, 1, statementCacheOf(instance).order.Len())
+	})
+
+	t.Run("Should use the cached statement in the transaction", func(t *testing.T) {
+		instance := openStatementCacheTestDB(t)
+		assert.NoError(t, NewStatement(ctx, query, "contact 1").ExecuteInInstance(instance))
+
+		tx, err := instance.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+		txCtx := withTransaction(ctx, instance, tx)
+		assert.NoError(t, NewStatement(txCtx, query, "contact 2").ExecuteInInstance(instance))
+		assert.NoError(t, tx.Rollback())
+
+		var count int
+		assert.NoError(t, instance.QueryRow("SELECT COUNT(*) FROM contacts").Scan(&count))
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Should prepare in the transaction when there is no free connection", func(t *testing.T) {
+		instance := openStatementCacheTestDB(t)
+
+		tx, err := instance.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+		txCtx := withTransaction(ctx, instance, tx)
+		assert.NoError(t, NewStatement(txCtx, query, "contact 1").ExecuteInInstance(instance))
+		assert.NoError(t, tx.Commit())
+
+		assert.False(t, statementCacheOf(instance).contains(query))
+	})
+
+	t.Run("Should not cache when the cache is disabled", func(t *testing.T) {
+		config.SQL_DB_STATEMENT_CACHE_SIZE = 0
+		t.Cleanup(func() { config.SQL_DB_STATEMENT_CACHE_SIZE = 100 })
+		instance := openStatementCacheTestDB(t)
+
+		assert.NoError(t, NewStatement(ctx, query, "contact 1").ExecuteInInstance(instance))
+		assert.Nil(t, statementCacheOf(instance))
+	})
+}
+
+func TestIsConnectionError(t *testing.T) {
+	t.Run("Should return true for connection errors", func(t *testing.T) {
+		assert.True(t, isConnectionError(driver.ErrBadConn))
+		assert.True(t, isConnectionError(fmt.Errorf("exec: %w", sql.ErrConnDone)))
+		assert.True(t, isConnectionError(&net.OpError{Op: "read", Err: errors.New("connection reset")}))
+	})
+
+	t.Run("Should return false for other errors", func(t *testing.T) {
+		assert.False(t, isConnectionError(nil))
+		assert.False(t, isConnectionError(errors.New("syntax error")))
+	})
+}
@@ -2,6 +2,7 @@ package sqlDB
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -20,6 +21,24 @@ func TestStatementWithoutInitialize(t *testing.T) {
 
 		assert.Error(t, err, db_not_initialized_error)
 	})
+
+	t.Run("Should return error when execute statement with rows affected", func(t *testing.T) {
+		affected, err := NewStatement(ctx, "DELETE FROM users").ExecuteRowsAffected()
+
+		assert.EqualError(t, err, db_not_initialized_error)
+		assert.Zero(t, affected)
+		assert.EqualError(t, NewStatement(ctx, "DELETE FROM users").ExecuteExpecting(1), db_not_initialized_error)
+	})
+
+	t.Run("Should return error when execute returning statement", func(t *testing.T) {
+		result, err := NewReturningStatement[User](ctx, "DELETE FROM users RETURNING id").One()
+		assert.EqualError(t, err, db_not_initialized_error)
+		assert.Nil(t, result)
+
+		list, err := NewReturningStatement[User](ctx, "DELETE FROM users RETURNING id").Many()
+		assert.EqualError(t, err, db_not_initialized_error)
+		assert.Nil(t, list)
+	})
 }
 
 func TestStatement(t *testing.T) {
@@ -47,4 +66,41 @@ func TestStatement(t *testing.T) {
 		assert.Equal(t, user.Birthday.Local(), result.Birthday.Local())
 		assert.Equal(t, user.Profile, result.Profile)
 	})
+
+	t.Run("Should return rows affected", func(t *testing.T) {
+		affected, err := NewStatement(ctx, "UPDATE users SET name = name WHERE profile_id = $1", 100).ExecuteRowsAffected()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), affected)
+	})
+
+	t.Run("Should return error when rows affected is not the expected", func(t *testing.T) {
+		err := NewStatement(ctx, "UPDATE users SET name = $1 WHERE id = $2", "NOT FOUND", 999).ExecuteExpecting(1)
+
+		assert.True(t, errors.Is(err, ErrUnexpectedRowsAffected))
+		assert.EqualError(t, err, "unexpected rows affected: expected 1 rows, affected 0")
+	})
+
+	t.Run("Should execute statement expecting rows affected", func(t *testing.T) {
+		err := NewStatement(ctx, "UPDATE users SET name = $1 WHERE id = $2", "Usuário teste stmt", 123).ExecuteExpecting(1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return values of returning statement", func(t *testing.T) {
+		type contact struct {
+			ID    int
+			Email string
+		}
+
+		result, err := NewReturningStatement[contact](ctx, "INSERT INTO contacts (name, email) VALUES ($1, $2) RETURNING id, email", "Returning", "returning@email.com").One()
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.NotZero(t, result.ID)
+		assert.Equal(t, "returning@email.com", result.Email)
+
+		ids, err := NewReturningStatement[int](ctx, "DELETE FROM contacts WHERE email = $1 RETURNING id", "returning@email.com").Many()
+		assert.NoError(t, err)
+		assert.Equal(t, []int{result.ID}, ids)
+	})
 }
@@ -46,4 +46,6 @@ func Initialize() {
 
 	logging.Info("Message broker connected")
 	observer.Attach(&messagingObserver{})
+
+	initializeOutbox()
 }
@@ -0,0 +1,236 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/logging"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/observer"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/database/sqlDB"
+)
+
+const (
+	outboxTable         string = "messaging_outbox"
+	outboxLockKey       int64  = 7_236_915_482_113
+	outboxMaxRetryDelay        = 5 * time.Minute
+
+	outboxCreateTableQuery string = `CREATE TABLE IF NOT EXISTS messaging_outbox (
+		id BIGSERIAL PRIMARY KEY,
+		topic VARCHAR(255) NOT NULL,
+		message TEXT NOT NULL,
+		attempts INT NOT NULL DEFAULT 0,
+		last_error TEXT,
+		next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+		created_at TIMESTAMP NOT NULL DEFAULT now()
+	)`
+	outboxCreateIndexQuery string = "CREATE INDEX IF NOT EXISTS messaging_outbox_topic_idx ON messaging_outbox (topic, id)"
+	outboxInsertQuery      string = "INSERT INTO messaging_outbox (topic, message) VALUES ($1, $2)"
+	outboxLockQuery        string = "SELECT pg_try_advisory_xact_lock($1)"
+	// the pending messages are the ones without a delayed message of the same topic before them,
+	// so the topics waiting for a retry don't fill the batch
+	outboxPendingQuery string = `SELECT id, topic, message, attempts FROM messaging_outbox o
+		WHERE NOT EXISTS (
+			SELECT 1 FROM messaging_outbox d WHERE d.topic = o.topic AND d.id <= o.id AND d.next_attempt_at > now()
+		)
+		ORDER BY id LIMIT $1`
+	outboxPendingMessageQuery string = `SELECT id, topic, message, attempts FROM messaging_outbox o
+		WHERE o.id = $1 AND NOT EXISTS (
+			SELECT 1 FROM messaging_outbox d WHERE d.topic = o.topic AND d.id <= o.id AND d.next_attempt_at > now()
+		)`
+	outboxDeleteQuery string = "DELETE FROM messaging_outbox WHERE id = $1"
+	outboxRetryQuery  string = "UPDATE messaging_outbox SET attempts = attempts + 1, last_error = $1, next_attempt_at = now() + $2 * INTERVAL '1 millisecond' WHERE id = $3"
+
+	outbox_create_table_error string = "could not create messaging outbox table: %v"
+	outbox_relay_error        string = "could not relay messaging outbox: %v"
+	outbox_publish_error      string = "could not publish outbox message %d to topic %s, attempt %d: %v"
+)
+
+// outboxMessage is a pending message of the outbox table.
+type outboxMessage struct {
+	Id       int64
+	Topic    string
+	Message  string
+	Attempts int
+}
+
+// outboxRelay delivers the pending messages of the outbox table to the message broker in background.
+type outboxRelay struct {
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+var relay *outboxRelay
+
+// initializeOutbox creates the outbox table and starts the relay when the outbox is enabled.
+// The sqlDB must be initialized before the messaging, and the relay is stopped before the database and the messaging are closed.
+func initializeOutbox() {
+	if !config.MESSAGING_OUTBOX_ENABLED {
+		return
+	}
+
+	for _, query := range []string{outboxCreateTableQuery, outboxCreateIndexQuery} {
+		if err := sqlDB.NewStatement(context.Background(), query).Execute(); err != nil {
+			logging.Fatal(outbox_create_table_error, err)
+		}
+	}
+
+	relay = &outboxRelay{
+		interval:  time.Duration(config.MESSAGING_OUTBOX_INTERVAL_SECONDS) * time.Second,
+		batchSize: config.MESSAGING_OUTBOX_BATCH_SIZE,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go relay.run()
+
+	logging.Info("Messaging outbox relay started")
+	observer.AttachFirst(relay)
+}
+
+// useOutbox checks if the message must be written in the outbox, that is when the outbox is enabled
+// and there is a sql transaction of the default datasource in the context. The outbox table and the relay
+// exist only in the default datasource, so the messages of named datasource transactions are published directly.
+func useOutbox(ctx context.Context) bool {
+	return relay != nil && sqlDB.InTransaction(sqlDB.WithDatasource(ctx, ""))
+}
+
+// saveOutbox writes the message in the outbox table with the transaction of the default datasource in the context,
+// so it's delivered by the relay only when the transaction is committed.
+func saveOutbox(ctx context.Context, p *Producer, msg *ProviderMessage) error {
+	message, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.NewStatement(sqlDB.WithDatasource(ctx, ""), outboxInsertQuery, p.topic, string(message)).Execute()
+}
+
+// Close stops the relay, waiting for the delivery in progress.
+func (r *outboxRelay) Close() {
+	logging.Info("waiting to safely close messaging outbox relay")
+	close(r.stop)
+	<-r.done
+}
+
+// run delivers the pending messages in each interval until the relay is stopped.
+func (r *outboxRelay) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			if err := r.deliver(context.Background()); err != nil {
+				logging.Error(outbox_relay_error, err)
+			}
+		}
+	}
+}
+
+// deliver publishes a batch of pending messages in the order they were written, deleting the delivered ones.
+// A failed message is retried later with backoff, and the next messages of its topic wait for it to keep the order,
+// without blocking the messages of the other topics. Each message is committed in its own transaction, so the
+// delivery is at-least-once: a message is published again when its delete is not committed.
+// The delivery stops when the relay is closed, after the message in progress.
+func (r *outboxRelay) deliver(ctx context.Context) error {
+	messages, err := sqlDB.NewQuery[outboxMessage](ctx, outboxPendingQuery, r.batchSize).Many()
+	if err != nil {
+		return err
+	}
+
+	blockedTopics := map[string]bool{}
+	for _, message := range messages {
+		if r.stopped() {
+			return nil
+		}
+
+		if blockedTopics[message.Topic] {
+			continue
+		}
+
+		locked, delivered, err := r.deliverMessage(ctx, message.Id)
+		if err != nil || !locked {
+			return err
+		}
+
+		if !delivered {
+			blockedTopics[message.Topic] = true
+		}
+	}
+
+	return nil
+}
+
+// deliverMessage publishes the message and deletes it, or schedules its retry when the publish fails, in a transaction
+// holding the advisory lock, so only one relay delivers at a time. The message is read again in the transaction,
+// since it may have been delivered or delayed by another relay.
+//
+// ctx: the context of the delivery.
+// id: the id of the outbox message.
+// Returns if the lock was acquired, if the message was delivered and an error.
+func (r *outboxRelay) deliverMessage(ctx context.Context, id int64) (locked, delivered bool, err error) {
+	err = sqlDB.NewTransaction().Execute(ctx, func(ctx context.Context) error {
+		acquired, err := sqlDB.NewQuery[bool](ctx, outboxLockQuery, outboxLockKey).One()
+		if err != nil || !*acquired {
+			return err
+		}
+		locked = true
+
+		message, err := sqlDB.NewQuery[outboxMessage](ctx, outboxPendingMessageQuery, id).One()
+		if err != nil || message == nil {
+			return err
+		}
+
+		if err = publishOutbox(ctx, *message); err != nil {
+			logging.Warn(outbox_publish_error, message.Id, message.Topic, message.Attempts+1, err)
+			delay := r.retryDelay(message.Attempts + 1)
+			return sqlDB.NewStatement(ctx, outboxRetryQuery, err.Error(), delay.Milliseconds(), message.Id).Execute()
+		}
+
+		if err = sqlDB.NewStatement(ctx, outboxDeleteQuery, message.Id).Execute(); err != nil {
+			return err
+		}
+		delivered = true
+		return nil
+	})
+
+	return locked, delivered && err == nil, err
+}
+
+// stopped checks if the relay is closed.
+func (r *outboxRelay) stopped() bool {
+	select {
+	case <-r.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay returns the exponential backoff of a failed message, limited to outboxMaxRetryDelay.
+func (r *outboxRelay) retryDelay(attempts int) time.Duration {
+	delay := r.interval << attempts
+	if delay <= 0 || delay > outboxMaxRetryDelay {
+		return outboxMaxRetryDelay
+	}
+
+	return delay
+}
+
+// publishOutbox publishes the outbox message to its topic.
+func publishOutbox(ctx context.Context, message outboxMessage) error {
+	var msg ProviderMessage
+	if err := json.Unmarshal([]byte(message.Message), &msg); err != nil {
+		return fmt.Errorf("invalid outbox message: %w", err)
+	}
+
+	return instance.producer(ctx, &Producer{message.Topic}, &msg)
+}
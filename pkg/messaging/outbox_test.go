@@ -0,0 +1,263 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/config"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/test"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/database/sqlDB"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/database/sqlDB/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRelay(t *testing.T) {
+	test.InitializeBaseTest()
+	assert.NoError(t, sqlite.Initialize(sqlite.MemoryDSN))
+	t.Cleanup(func() { relay = nil })
+
+	inTransaction := func(ctx context.Context, fn func(ctx context.Context)) {
+		assert.NoError(t, sqlDB.NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			fn(ctx)
+			return nil
+		}))
+	}
+
+	t.Run("Should not use outbox when it's disabled", func(t *testing.T) {
+		relay = nil
+
+		inTransaction(context.Background(), func(ctx context.Context) {
+			assert.False(t, useOutbox(ctx))
+		})
+	})
+
+	t.Run("Should use outbox only inside a transaction", func(t *testing.T) {
+		relay = &outboxRelay{interval: time.Second}
+
+		assert.False(t, useOutbox(context.Background()))
+		inTransaction(context.Background(), func(ctx context.Context) {
+			assert.True(t, useOutbox(ctx))
+			assert.True(t, useOutbox(sqlDB.WithDatasource(ctx, "REPORTING")))
+		})
+	})
+
+	t.Run("Should not use outbox without a transaction of the default datasource", func(t *testing.T) {
+		relay = &outboxRelay{interval: time.Second}
+
+		assert.False(t, useOutbox(sqlDB.WithDatasource(context.Background(), "REPORTING")))
+	})
+
+	t.Run("Should return retry delay with exponential backoff", func(t *testing.T) {
+		r := &outboxRelay{interval: time.Second}
+
+		assert.Equal(t, 2*time.Second, r.retryDelay(1))
+		assert.Equal(t, 8*time.Second, r.retryDelay(3))
+		assert.Equal(t, outboxMaxRetryDelay, r.retryDelay(20))
+		assert.Equal(t, outboxMaxRetryDelay, r.retryDelay(100))
+	})
+
+	t.Run("Should stop the relay when closed", func(t *testing.T) {
+		r := &outboxRelay{interval: time.Hour, stop: make(chan struct{}), done: make(chan struct{})}
+		go r.run()
+
+		r.Close()
+
+		_, open := <-r.done
+		assert.False(t, open)
+	})
+}
+
+func TestOutbox_AWS(t *testing.T) {
+	test.InitializeSqlDBTest()
+	sqlDB.Initialize()
+	test.InitializeTestLocalstack()
+	config.MESSAGING_OUTBOX_ENABLED = true
+	config.MESSAGING_OUTBOX_BATCH_SIZE = 2
+	t.Cleanup(func() {
+		config.MESSAGING_OUTBOX_ENABLED = false
+		config.MESSAGING_OUTBOX_BATCH_SIZE = 100
+		relay.Close()
+		relay = nil
+	})
+
+	Initialize()
+
+	t.Run("Should not publish message when the transaction is rolled back", func(t *testing.T) {
+		ctx := context.Background()
+		err := sqlDB.NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			if err := NewProducer(testTopicName).Publish(ctx, "create", userMessageTest{"Rollback", "rollback@email.com"}); err != nil {
+				return err
+			}
+			return errors.New("rollback")
+		})
+		count, countErr := sqlDB.NewQuery[int](ctx, "SELECT COUNT(*) FROM messaging_outbox").One()
+
+		assert.Error(t, err)
+		assert.NoError(t, countErr)
+		assert.Zero(t, *count)
+	})
+
+	t.Run("Should publish message after the transaction is committed", func(t *testing.T) {
+		chSuccess := make(chan *ProviderMessage)
+		NewConsumer(&queueConsumerTest{
+			fn: func(ctx context.Context, message *ProviderMessage) error {
+				chSuccess <- message
+				return nil
+			},
+			qName: testQueueName,
+		})
+
+		err := sqlDB.NewTransaction().Execute(context.Background(), func(ctx context.Context) error {
+			return NewProducer(testTopicName).Publish(ctx, "create", userMessageTest{"Commit", "commit@email.com"})
+		})
+		assert.NoError(t, err)
+
+		select {
+		case message := <-chSuccess:
+			var model userMessageTest
+			assert.NoError(t, message.DecodeMessage(&model))
+			assert.Equal(t, "commit@email.com", model.Email)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test didn't finish after 5s")
+		}
+	})
+
+	t.Run("Should publish message of a topic while another topic is waiting for retry", func(t *testing.T) {
+		ctx := context.Background()
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, sqlDB.NewStatement(ctx, "INSERT INTO messaging_outbox (topic, message, attempts, next_attempt_at) VALUES ($1, $2, 1, now() + INTERVAL '1 hour')", "stuck-topic", "{}").Execute())
+		}
+		t.Cleanup(func() {
+			sqlDB.NewStatement(ctx, "DELETE FROM messaging_outbox WHERE topic = $1", "stuck-topic").Execute()
+		})
+
+		chSuccess := make(chan *ProviderMessage)
+		NewConsumer(&queueConsumerTest{
+			fn: func(ctx context.Context, message *ProviderMessage) error {
+				chSuccess <- message
+				return nil
+			},
+			qName: testQueueName,
+		})
+
+		err := sqlDB.NewTransaction().Execute(ctx, func(ctx context.Context) error {
+			return NewProducer(testTopicName).Publish(ctx, "create", userMessageTest{"Other topic", "other@email.com"})
+		})
+		assert.NoError(t, err)
+
+		select {
+		case message := <-chSuccess:
+			var model userMessageTest
+			assert.NoError(t, message.DecodeMessage(&model))
+			assert.Equal(t, "other@email.com", model.Email)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test didn't finish after 5s")
+		}
+	})
+}
+
+type blockingMessagingTest struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingMessagingTest) producer(context.Context, *Producer, *ProviderMessage) error {
+	m.started <- struct{}{}
+	<-m.release
+	return nil
+}
+
+func (m *blockingMessagingTest) consumer(context.Context, *consumer) (chan *ProviderMessage, error) {
+	return nil, nil
+}
+
+func TestOutboxShutdown(t *testing.T) {
+	ctx := context.Background()
+	test.InitializeSqlDBTest()
+	sqlDB.Initialize()
+	assert.NoError(t, sqlDB.NewStatement(ctx, outboxCreateTableQuery).Execute())
+
+	broker := &blockingMessagingTest{started: make(chan struct{}, 1), release: make(chan struct{})}
+	previous := instance
+	instance = broker
+	t.Cleanup(func() { instance = previous })
+
+	t.Run("Should wait for the running delivery when the relay is closed", func(t *testing.T) {
+		assert.NoError(t, sqlDB.NewStatement(ctx, outboxInsertQuery, "shutdown-topic", "{}").Execute())
+		r := &outboxRelay{interval: 10 * time.Millisecond, batchSize: 10, stop: make(chan struct{}), done: make(chan struct{})}
+		go r.run()
+
+		select {
+		case <-broker.started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test didn't finish after 5s")
+		}
+
+		closed := make(chan struct{})
+		go func() {
+			r.Close()
+			close(closed)
+		}()
+
+		select {
+		case <-closed:
+			t.Fatal("relay closed while a delivery is running")
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		close(broker.release)
+		<-closed
+
+		count, err := sqlDB.NewQuery[int](ctx, "SELECT COUNT(*) FROM messaging_outbox WHERE topic = $1", "shutdown-topic").One()
+		assert.NoError(t, err)
+		assert.Zero(t, *count)
+	})
+}
+
+type failingMessagingTest struct {
+	topic string
+}
+
+func (m *failingMessagingTest) producer(_ context.Context, p *Producer, _ *ProviderMessage) error {
+	if p.topic == m.topic {
+		return errors.New("publish error")
+	}
+	return nil
+}
+
+func (m *failingMessagingTest) consumer(context.Context, *consumer) (chan *ProviderMessage, error) {
+	return nil, nil
+}
+
+func TestOutboxDelivery(t *testing.T) {
+	ctx := context.Background()
+	test.InitializeSqlDBTest()
+	sqlDB.Initialize()
+	assert.NoError(t, sqlDB.NewStatement(ctx, outboxCreateTableQuery).Execute())
+
+	previous := instance
+	instance = &failingMessagingTest{topic: "failing-topic"}
+	t.Cleanup(func() {
+		instance = previous
+		sqlDB.NewStatement(ctx, "DELETE FROM messaging_outbox WHERE topic IN ($1, $2)", "failing-topic", "delivered-topic").Execute()
+	})
+
+	t.Run("Should commit each message when another message of the batch fails", func(t *testing.T) {
+		for _, topic := range []string{"delivered-topic", "failing-topic", "delivered-topic"} {
+			assert.NoError(t, sqlDB.NewStatement(ctx, outboxInsertQuery, topic, "{}").Execute())
+		}
+		r := &outboxRelay{interval: time.Second, batchSize: 10, stop: make(chan struct{}), done: make(chan struct{})}
+
+		assert.NoError(t, r.deliver(ctx))
+
+		delivered, err := sqlDB.NewQuery[int](ctx, "SELECT COUNT(*) FROM messaging_outbox WHERE topic = $1", "delivered-topic").One()
+		assert.NoError(t, err)
+		assert.Zero(t, *delivered)
+
+		attempts, err := sqlDB.NewQuery[int](ctx, "SELECT attempts FROM messaging_outbox WHERE topic = $1", "failing-topic").One()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *attempts)
+	})
+}
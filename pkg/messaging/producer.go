@@ -20,6 +20,8 @@ func NewProducer(topicName string) *Producer {
 	return &Producer{topicName}
 }
 
+// Publish sends the message to the topic. When the outbox is enabled and there is a sql transaction of the default
+// datasource in the context, the message is written in the outbox table and delivered by the relay after the transaction is committed.
 func (p *Producer) Publish(ctx context.Context, action string, message any) error {
 	if instance == nil {
 		return errors.New("messaging has not been initialized. add in main.go `messaging.Initialize()`")
@@ -53,6 +55,16 @@ func (p *Producer) Publish(ctx context.Context, action string, message any) erro
 		msg.UserId = authContext.GetUserID()
 	}
 
+	if useOutbox(ctx) {
+		if err := saveOutbox(ctx, p, msg); err != nil {
+			logging.Error("Could not save message with id %s to outbox of topic %s. Error: %v", msg.Id, p.topic, err)
+			monitoring.NoticeError(txn, err)
+			return err
+		}
+
+		return nil
+	}
+
 	if err := instance.producer(ctx, p, msg); err != nil {
 		logging.Error("Could not send message with id %s to topic %s. Error: %v", msg.Id, p.topic, err)
 		monitoring.NoticeError(txn, err)
@@ -0,0 +1,97 @@
+package restserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/validator"
+)
+
+const (
+	pageRequestPageParam      string = "page"
+	pageRequestSizeParam      string = "size"
+	pageRequestSortParam      string = "sort"
+	pageRequestSortsSeparator string = ";"
+	pageRequestSortSeparator  string = ","
+
+	pageRequestDefaultPage uint16 = 1
+	pageRequestDefaultSize uint16 = 20
+
+	page_request_param_error string = "invalid %s param: %w"
+)
+
+// pageRequestParams is the contract of the page request query params
+type pageRequestParams struct {
+	Page uint16            `validate:"min=1"`
+	Size uint16            `validate:"min=1"`
+	Sort []pageRequestSort `validate:"dive"`
+}
+
+// pageRequestSort is the contract of the page request sort query param
+type pageRequestSort struct {
+	Field     string              `validate:"required"`
+	Direction types.SortDirection `validate:"sort-direction"`
+}
+
+// ParsePageRequest parses the ?page=1&size=20&sort=name,desc query params into a validated types.PageRequest.
+// Many sorts are separated by semicolon (ex: sort=name,desc;birthday) and the direction is ASC by default.
+// The sort fields are resolved by the sortable fields allow-list, so only the allowed fields reach the ORDER BY clause.
+//
+// ctx: the web context of the request
+// sortable: the allow-list of the sortable fields, mapping the API field names to the SQL expressions
+// Returns a pointer to types.PageRequest and an error when a param is invalid or a sort field is not allowed.
+func ParsePageRequest(ctx WebContext, sortable types.SortableFields) (*types.PageRequest, error) {
+	params := pageRequestParams{Page: pageRequestDefaultPage, Size: pageRequestDefaultSize}
+	if err := parsePageRequestNumber(ctx, pageRequestPageParam, &params.Page); err != nil {
+		return nil, err
+	}
+
+	if err := parsePageRequestNumber(ctx, pageRequestSizeParam, &params.Size); err != nil {
+		return nil, err
+	}
+
+	for _, sort := range strings.Split(ctx.QueryParam(pageRequestSortParam), pageRequestSortsSeparator) {
+		if sort = strings.TrimSpace(sort); sort == "" {
+			continue
+		}
+
+		field, direction, _ := strings.Cut(sort, pageRequestSortSeparator)
+		if direction = strings.ToUpper(strings.TrimSpace(direction)); direction == "" {
+			direction = string(types.ASC)
+		}
+		params.Sort = append(params.Sort, pageRequestSort{strings.TrimSpace(field), types.SortDirection(direction)})
+	}
+
+	if err := validator.Struct(params); err != nil {
+		return nil, err
+	}
+
+	order := make([]types.Sort, 0, len(params.Sort))
+	for _, sort := range params.Sort {
+		resolved, err := sortable.NewSort(sort.Direction, sort.Field)
+		if err != nil {
+			return nil, err
+		}
+		order = append(order, resolved)
+	}
+
+	return types.NewPageRequest(params.Page, params.Size, order), nil
+}
+
+// parsePageRequestNumber parses the query param into the number when it is informed.
+func parsePageRequestNumber(ctx WebContext, param string, number *uint16) error {
+	value := ctx.QueryParam(param)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return fmt.Errorf(page_request_param_error, param, err)
+	}
+
+	*number = uint16(parsed)
+	return nil
+}
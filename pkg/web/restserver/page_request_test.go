@@ -0,0 +1,63 @@
+package restserver
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/types"
+	"github.com/colibri-project-io/colibri-sdk-go/pkg/base/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePageRequest(t *testing.T) {
+	validator.Initialize()
+	sortable := types.SortableFields{"name": "u.name", "birthday": "u.birthday"}
+
+	parse := func(url string) (result *types.PageRequest, err error) {
+		NewRequestTest(&RequestTest{Method: http.MethodGet, Url: url, Path: "/users"}, func(ctx WebContext) {
+			result, err = ParsePageRequest(ctx, sortable)
+			ctx.EmptyResponse(http.StatusNoContent)
+		})
+		return
+	}
+
+	t.Run("Should return default page request when params are empty", func(t *testing.T) {
+		result, err := parse("/users")
+
+		assert.NoError(t, err)
+		assert.Equal(t, types.NewPageRequest(1, 20, []types.Sort{}), result)
+	})
+
+	t.Run("Should return page request with sorts resolved by the allow-list", func(t *testing.T) {
+		result, err := parse("/users?page=2&size=10&sort=name,desc;birthday")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(2), result.Page)
+		assert.Equal(t, uint16(10), result.Size)
+		assert.Equal(t, "u.name DESC, u.birthday ASC", result.GetOrder())
+		assert.NoError(t, result.Validate())
+	})
+
+	t.Run("Should return error when sort field is not allowed", func(t *testing.T) {
+		result, err := parse("/users?sort=password,asc")
+
+		assert.EqualError(t, err, "sort field password is not allowed")
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should return error when sort direction is invalid", func(t *testing.T) {
+		result, err := parse("/users?sort=name,sideways")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should return error when page params are invalid", func(t *testing.T) {
+		for _, url := range []string{"/users?page=0", "/users?size=0", "/users?page=x", "/users?size=-1"} {
+			result, err := parse(url)
+
+			assert.Error(t, err, url)
+			assert.Nil(t, result, url)
+		}
+	})
+}